@@ -44,6 +44,9 @@ func Execute() error {
 func init() {
 	rootCmd.AddCommand(runCmd)
 	rootCmd.AddCommand(utilsBootstrapCmd)
+	rootCmd.AddCommand(utilsKeygenCmd)
+	rootCmd.AddCommand(utilsSignCmd)
+	rootCmd.AddCommand(utilsDecodeTxCmd)
 }
 
 // handleSignals handles OS signals so we can ensure we close database
@@ -99,7 +99,16 @@ func runRunCmd(cmd *cobra.Command, args []string) error {
 		defer client.Close()
 	}
 
-	router := services.NewBlockchainRouter(cfg, client, asserter)
+	// Submitted transactions dropped from the txpool
+	// are rebroadcast if a rebroadcast interval is set.
+	tracker := services.NewTransactionTracker(cfg, client)
+	if cfg.Mode == configuration.Online {
+		g.Go(func() error {
+			return tracker.Rebroadcast(ctx)
+		})
+	}
+
+	router := services.NewBlockchainRouter(cfg, client, tracker, asserter)
 
 	loggedRouter := server.LoggerMiddleware(router)
 	corsRouter := server.CorsMiddleware(loggedRouter)
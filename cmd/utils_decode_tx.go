@@ -0,0 +1,157 @@
+// Copyright 2020 Coinbase, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/metadium/rosetta-metadium/configuration"
+	"github.com/metadium/rosetta-metadium/metadium"
+	"github.com/metadium/rosetta-metadium/services"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/spf13/cobra"
+)
+
+var (
+	utilsDecodeTxCmd = &cobra.Command{
+		Use:   "utils:decode-tx",
+		Short: "Decode a signed transaction",
+		Long: `To inspect a signed transaction, it can be useful to
+decode it without connecting to gmet. This command prints
+the fields of a signed transaction, given as gmet JSON or
+as raw RLP, and the operations /construction/parse returns
+for it.
+
+When calling this command, you must provide 1 argument:
+[1] the 0x-prefixed raw transaction or the location of a
+file holding the raw or JSON transaction`,
+		RunE: runUtilsDecodeTxCmd,
+		Args: cobra.ExactArgs(1),
+	}
+)
+
+func runUtilsDecodeTxCmd(cmd *cobra.Command, args []string) error {
+	data := []byte(args[0])
+	if !strings.HasPrefix(args[0], "0x") {
+		fileData, err := ioutil.ReadFile(args[0])
+		if err != nil {
+			return fmt.Errorf("%w: unable to read transaction file", err)
+		}
+		data = fileData
+	}
+
+	signedTx, feeDelegatedTx, err := services.DecodeSignedTransaction(data)
+	if err != nil {
+		return fmt.Errorf("%w: unable to decode transaction", err)
+	}
+
+	cfg, err := decodeConfiguration(signedTx)
+	if err != nil {
+		return err
+	}
+
+	sender, err := ethTypes.Sender(ethTypes.NewLondonSigner(signedTx.ChainId()), signedTx)
+	if err != nil {
+		return fmt.Errorf("%w: unable to recover sender", err)
+	}
+
+	hash := signedTx.Hash()
+	txType := fmt.Sprintf("%d", signedTx.Type())
+	if feeDelegatedTx != nil {
+		hash = feeDelegatedTx.Hash()
+		txType = fmt.Sprintf("%d (fee-delegated)", metadium.FeeDelegateDynamicFeeTxType)
+	}
+
+	fmt.Printf("hash: %s\n", hash.Hex())
+	fmt.Printf("type: %s\n", txType)
+	fmt.Printf("chain id: %s (%s)\n", signedTx.ChainId(), cfg.Network.Network)
+	fmt.Printf("from: %s\n", sender.Hex())
+	if signedTx.To() != nil {
+		fmt.Printf("to: %s\n", signedTx.To().Hex())
+	} else {
+		fmt.Println("to: contract creation")
+	}
+	fmt.Printf("value: %s\n", signedTx.Value())
+	fmt.Printf("nonce: %d\n", signedTx.Nonce())
+	fmt.Printf("gas limit: %d\n", signedTx.Gas())
+	if signedTx.Type() == ethTypes.DynamicFeeTxType {
+		fmt.Printf("max fee per gas: %s\n", signedTx.GasFeeCap())
+		fmt.Printf("max priority fee per gas: %s\n", signedTx.GasTipCap())
+	} else {
+		fmt.Printf("gas price: %s\n", signedTx.GasPrice())
+	}
+	if len(signedTx.AccessList()) > 0 {
+		fmt.Printf("access list: %s\n", types.PrettyPrintStruct(signedTx.AccessList()))
+	}
+	if feeDelegatedTx != nil {
+		feePayer, err := feeDelegatedTx.FeePayerSender()
+		if err != nil {
+			return fmt.Errorf("%w: unable to recover fee payer", err)
+		}
+		fmt.Printf("fee payer: %s\n", feePayer.Hex())
+	}
+
+	// /construction/parse does not use gmet, so the
+	// construction service is created without a client.
+	servicer := services.NewConstructionAPIService(cfg, nil, nil)
+	parseResponse, rErr := servicer.ConstructionParse(
+		context.Background(),
+		&types.ConstructionParseRequest{
+			NetworkIdentifier: cfg.Network,
+			Signed:            true,
+			Transaction:       string(data),
+		},
+	)
+	if rErr != nil {
+		return fmt.Errorf("unable to parse transaction: %s", types.PrintStruct(rErr))
+	}
+
+	fmt.Printf("operations: %s\n", types.PrettyPrintStruct(parseResponse.Operations))
+	return nil
+}
+
+// decodeConfiguration returns the offline configuration
+// of the network of the chain ID of signedTx.
+func decodeConfiguration(signedTx *ethTypes.Transaction) (*configuration.Configuration, error) {
+	cfg := &configuration.Configuration{
+		Mode: configuration.Offline,
+	}
+
+	switch {
+	case signedTx.ChainId().Cmp(params.MetadiumMainnetChainConfig.ChainID) == 0:
+		cfg.Network = &types.NetworkIdentifier{
+			Blockchain: metadium.Blockchain,
+			Network:    metadium.MainnetNetwork,
+		}
+		cfg.Params = params.MetadiumMainnetChainConfig
+	case signedTx.ChainId().Cmp(params.MetadiumTestnetChainConfig.ChainID) == 0:
+		cfg.Network = &types.NetworkIdentifier{
+			Blockchain: metadium.Blockchain,
+			Network:    metadium.TestnetNetwork,
+		}
+		cfg.Params = params.MetadiumTestnetChainConfig
+	default:
+		return nil, errors.New("transaction is not for the Metadium mainnet or testnet")
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,84 @@
+// Copyright 2020 Coinbase, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+)
+
+var (
+	utilsKeygenCmd = &cobra.Command{
+		Use:   "utils:keygen",
+		Short: "Generate a secp256k1 key in a keystore file",
+		Long: `To exercise the construction flow without rosetta-cli,
+it can be useful to generate keys to sign transactions
+with utils:sign. This command generates a secp256k1 key
+and stores it in a go-ethereum keystore file, encrypted
+with the password provided with --password. The address
+and the compressed public key of the key are printed,
+to fund the account and to call /construction/derive.
+
+When calling this command, you must provide 1 argument:
+[1] the directory where to write the keystore file`,
+		RunE: runUtilsKeygenCmd,
+		Args: cobra.ExactArgs(1),
+	}
+
+	// keystorePassword is the password encrypting
+	// the keystore files of utils:keygen and utils:sign.
+	keystorePassword string
+)
+
+func init() {
+	utilsKeygenCmd.Flags().StringVar(
+		&keystorePassword,
+		"password",
+		"",
+		"password encrypting the keystore file",
+	)
+}
+
+func runUtilsKeygenCmd(cmd *cobra.Command, args []string) error {
+	account, err := keystore.StoreKey(
+		args[0],
+		keystorePassword,
+		keystore.StandardScryptN,
+		keystore.StandardScryptP,
+	)
+	if err != nil {
+		return fmt.Errorf("%w: unable to generate key", err)
+	}
+
+	keyJSON, err := ioutil.ReadFile(account.URL.Path)
+	if err != nil {
+		return fmt.Errorf("%w: unable to read keystore file", err)
+	}
+
+	key, err := keystore.DecryptKey(keyJSON, keystorePassword)
+	if err != nil {
+		return fmt.Errorf("%w: unable to decrypt keystore file", err)
+	}
+
+	fmt.Printf("address: %s\n", account.Address.Hex())
+	fmt.Printf("public key: %s\n", hexutil.Encode(crypto.CompressPubkey(&key.PrivateKey.PublicKey)))
+	fmt.Printf("keystore file: %s\n", account.URL.Path)
+	return nil
+}
@@ -0,0 +1,176 @@
+// Copyright 2020 Coinbase, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/metadium/rosetta-metadium/configuration"
+	"github.com/metadium/rosetta-metadium/metadium"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+)
+
+var (
+	utilsSignCmd = &cobra.Command{
+		Use:   "utils:sign",
+		Short: "Sign the payloads of a /construction/payloads response",
+		Long: `To exercise the construction flow without rosetta-cli,
+it can be useful to sign transactions offline. This command
+signs the payloads of a /construction/payloads response with
+the keys of go-ethereum keystore files, like those generated
+by utils:keygen, and writes the /construction/combine request
+of the signed payloads. Each payload is signed by the key of
+its account.
+
+Fee-delegated transactions are signed in two rounds. First,
+the sender signs the payloads response and /construction/combine
+returns the sender-signed transaction. Then, the fee payer signs
+the payloads response of the metadata with the sender_signature
+of that transaction, and /construction/combine returns the
+fee-delegated transaction.
+
+When calling this command, you must provide at least 3 arguments:
+[1] the location of the /construction/payloads response file
+[2] the location of where to write the /construction/combine request file
+[3...] the locations of the keystore files`,
+		RunE: runUtilsSignCmd,
+		Args: cobra.MinimumNArgs(3), //nolint:gomnd
+	}
+
+	// signNetwork is the network of the
+	// /construction/combine request.
+	signNetwork string
+)
+
+func init() {
+	utilsSignCmd.Flags().StringVar(
+		&keystorePassword,
+		"password",
+		"",
+		"password decrypting the keystore files",
+	)
+	utilsSignCmd.Flags().StringVar(
+		&signNetwork,
+		"network",
+		configuration.Mainnet,
+		fmt.Sprintf("network of the transaction (%s or %s)", configuration.Mainnet, configuration.Testnet),
+	)
+}
+
+func runUtilsSignCmd(cmd *cobra.Command, args []string) error {
+	networkIdentifier := &types.NetworkIdentifier{
+		Blockchain: metadium.Blockchain,
+	}
+	switch signNetwork {
+	case configuration.Mainnet:
+		networkIdentifier.Network = metadium.MainnetNetwork
+	case configuration.Testnet:
+		networkIdentifier.Network = metadium.TestnetNetwork
+	default:
+		return fmt.Errorf("%s is not a valid network", signNetwork)
+	}
+
+	payloadsJSON, err := ioutil.ReadFile(args[0])
+	if err != nil {
+		return fmt.Errorf("%w: unable to read payloads file", err)
+	}
+
+	var payloads types.ConstructionPayloadsResponse
+	if err := json.Unmarshal(payloadsJSON, &payloads); err != nil {
+		return fmt.Errorf("%w: unable to parse payloads file", err)
+	}
+
+	keys := map[common.Address]*ecdsa.PrivateKey{}
+	for _, path := range args[2:] {
+		keyJSON, err := ioutil.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("%w: unable to read keystore file %s", err, path)
+		}
+
+		key, err := keystore.DecryptKey(keyJSON, keystorePassword)
+		if err != nil {
+			return fmt.Errorf("%w: unable to decrypt keystore file %s", err, path)
+		}
+		keys[key.Address] = key.PrivateKey
+	}
+
+	signatures := make([]*types.Signature, len(payloads.Payloads))
+	for i, payload := range payloads.Payloads {
+		signature, err := signPayload(payload, keys)
+		if err != nil {
+			return err
+		}
+		signatures[i] = signature
+	}
+
+	combineJSON, err := json.MarshalIndent(&types.ConstructionCombineRequest{
+		NetworkIdentifier:   networkIdentifier,
+		UnsignedTransaction: payloads.UnsignedTransaction,
+		Signatures:          signatures,
+	}, "", " ")
+	if err != nil {
+		return fmt.Errorf("%w: unable to marshal combine request", err)
+	}
+
+	if err := ioutil.WriteFile(args[1], combineJSON, 0600); err != nil { //nolint:gomnd
+		return fmt.Errorf("%w: unable to write combine request file", err)
+	}
+
+	return nil
+}
+
+// signPayload signs payload with the key of its account. Payloads
+// of the ecdsa signature type are signed without recovery id.
+func signPayload(
+	payload *types.SigningPayload,
+	keys map[common.Address]*ecdsa.PrivateKey,
+) (*types.Signature, error) {
+	address := payload.AccountIdentifier.Address
+	key, ok := keys[common.HexToAddress(address)]
+	if !ok {
+		return nil, fmt.Errorf("no keystore file provided for %s", address)
+	}
+
+	signature, err := crypto.Sign(payload.Bytes, key)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unable to sign payload of %s", err, address)
+	}
+
+	signatureType := payload.SignatureType
+	if len(signatureType) == 0 {
+		signatureType = types.EcdsaRecovery
+	}
+	if signatureType == types.Ecdsa {
+		signature = signature[:crypto.RecoveryIDOffset]
+	}
+
+	return &types.Signature{
+		SigningPayload: payload,
+		PublicKey: &types.PublicKey{
+			Bytes:     crypto.CompressPubkey(&key.PublicKey),
+			CurveType: types.Secp256k1,
+		},
+		SignatureType: signatureType,
+		Bytes:         signature,
+	}, nil
+}
@@ -17,8 +17,10 @@ package configuration
 import (
 	"errors"
 	"fmt"
+	"math/big"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/coinbase/rosetta-sdk-go/types"
 	"github.com/ethereum/go-ethereum/params"
@@ -76,6 +78,49 @@ const (
 	// by hosted node services. When not set, defaults to false.
 	SkipGmetAdminEnv = "SKIP_GMET_ADMIN"
 
+	// GasLimitMarginEnv is an optional environment variable
+	// used to set the percentage added to the gas estimated
+	// for a transaction in /construction/metadata.
+	GasLimitMarginEnv = "GAS_LIMIT_MARGIN"
+
+	// DefaultGasLimitMargin is the default percentage added
+	// to estimated gas. This is used when GasLimitMarginEnv
+	// is not populated.
+	DefaultGasLimitMargin = 20
+
+	// NonceTTLEnv is an optional environment variable used
+	// to set how many seconds a nonce handed out by
+	// /construction/metadata stays reserved for its sender.
+	NonceTTLEnv = "NONCE_TTL"
+
+	// DefaultNonceTTL is the default duration of a nonce
+	// reservation. This is used when NonceTTLEnv is not
+	// populated.
+	DefaultNonceTTL = 2 * time.Minute
+
+	// MaxGasPriceEnv is an optional environment variable used
+	// to set the maximum gas price, in wei, of a constructed
+	// transaction. For EIP-1559 transactions, the max fee per
+	// gas is checked. When not set, the gas price is not
+	// capped.
+	MaxGasPriceEnv = "MAX_GAS_PRICE"
+
+	// MaxFeeEnv is an optional environment variable used to
+	// set the maximum fee, in wei, a constructed transaction
+	// can be charged. When not set, the fee is not capped.
+	MaxFeeEnv = "MAX_FEE"
+
+	// MaxValueEnv is an optional environment variable used to
+	// set the maximum META value, in wei, of a constructed
+	// transaction. When not set, the value is not capped.
+	MaxValueEnv = "MAX_VALUE"
+
+	// RebroadcastIntervalEnv is an optional environment variable
+	// used to set how many seconds pass between rebroadcasts of
+	// submitted transactions that were dropped from the txpool.
+	// When not set, transactions are not rebroadcast.
+	RebroadcastIntervalEnv = "REBROADCAST_INTERVAL"
+
 	// MiddlewareVersion is the version of rosetta-metadium.
 	MiddlewareVersion = "0.0.4"
 )
@@ -90,6 +135,16 @@ type Configuration struct {
 	Port                   int
 	GmetArguments          string
 	SkipGmetAdmin          bool
+	GasLimitMargin         uint64
+	NonceTTL               time.Duration
+
+	// Caps of constructed transactions. A nil cap
+	// is not enforced.
+	MaxGasPrice *big.Int
+	MaxFee      *big.Int
+	MaxValue    *big.Int
+
+	RebroadcastInterval time.Duration
 
 	// Block Reward Data
 	Params *params.ChainConfig
@@ -154,6 +209,57 @@ func LoadConfiguration() (*Configuration, error) {
 		config.SkipGmetAdmin = val
 	}
 
+	config.GasLimitMargin = DefaultGasLimitMargin
+	envGasLimitMargin := os.Getenv(GasLimitMarginEnv)
+	if len(envGasLimitMargin) > 0 {
+		val, err := strconv.ParseUint(envGasLimitMargin, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: unable to parse GAS_LIMIT_MARGIN %s", err, envGasLimitMargin)
+		}
+		config.GasLimitMargin = val
+	}
+
+	config.NonceTTL = DefaultNonceTTL
+	envNonceTTL := os.Getenv(NonceTTLEnv)
+	if len(envNonceTTL) > 0 {
+		val, err := strconv.ParseUint(envNonceTTL, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: unable to parse NONCE_TTL %s", err, envNonceTTL)
+		}
+		config.NonceTTL = time.Duration(val) * time.Second
+	}
+
+	maxGasPrice, err := loadWei(MaxGasPriceEnv, nil)
+	if err != nil {
+		return nil, err
+	}
+	config.MaxGasPrice = maxGasPrice
+
+	maxFee, err := loadWei(MaxFeeEnv, nil)
+	if err != nil {
+		return nil, err
+	}
+	config.MaxFee = maxFee
+
+	maxValue, err := loadWei(MaxValueEnv, nil)
+	if err != nil {
+		return nil, err
+	}
+	config.MaxValue = maxValue
+
+	envRebroadcastInterval := os.Getenv(RebroadcastIntervalEnv)
+	if len(envRebroadcastInterval) > 0 {
+		val, err := strconv.ParseUint(envRebroadcastInterval, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"%w: unable to parse REBROADCAST_INTERVAL %s",
+				err,
+				envRebroadcastInterval,
+			)
+		}
+		config.RebroadcastInterval = time.Duration(val) * time.Second
+	}
+
 	portValue := os.Getenv(PortEnv)
 	if len(portValue) == 0 {
 		return nil, errors.New("PORT must be populated")
@@ -167,3 +273,19 @@ func LoadConfiguration() (*Configuration, error) {
 
 	return config, nil
 }
+
+// loadWei parses the amount of wei in the environment variable
+// env, returning defaultValue if it is not populated.
+func loadWei(env string, defaultValue *big.Int) (*big.Int, error) {
+	value := os.Getenv(env)
+	if len(value) == 0 {
+		return defaultValue, nil
+	}
+
+	wei, ok := new(big.Int).SetString(value, 10) // nolint:gomnd
+	if !ok || wei.Sign() < 0 {
+		return nil, fmt.Errorf("unable to parse %s %s", env, value)
+	}
+
+	return wei, nil
+}
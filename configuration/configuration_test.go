@@ -16,8 +16,10 @@ package configuration
 
 import (
 	"errors"
+	"math/big"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/metadium/rosetta-metadium/metadium"
 	// "github.com/metadium/rosetta-metadium/params"
@@ -29,11 +31,17 @@ import (
 
 func TestLoadConfiguration(t *testing.T) {
 	tests := map[string]struct {
-		Mode          string
-		Network       string
-		Port          string
-		Gmet          string
-		SkipGmetAdmin string
+		Mode           string
+		Network        string
+		Port           string
+		Gmet           string
+		SkipGmetAdmin  string
+		GasLimitMargin string
+		NonceTTL       string
+		MaxGasPrice    string
+		MaxFee         string
+		MaxValue       string
+		Rebroadcast    string
 
 		cfg *Configuration
 		err error
@@ -67,6 +75,8 @@ func TestLoadConfiguration(t *testing.T) {
 				GmetURL:                DefaultGmetURL,
 				GmetArguments:          metadium.MainnetGmetArguments,
 				SkipGmetAdmin:          false,
+				GasLimitMargin:         DefaultGasLimitMargin,
+				NonceTTL:               DefaultNonceTTL,
 			},
 		},
 		"all set (mainnet) + gmet": {
@@ -88,6 +98,8 @@ func TestLoadConfiguration(t *testing.T) {
 				RemoteGmet:             true,
 				GmetArguments:          metadium.MainnetGmetArguments,
 				SkipGmetAdmin:          true,
+				GasLimitMargin:         DefaultGasLimitMargin,
+				NonceTTL:               DefaultNonceTTL,
 			},
 		},
 		"all set (testnet)": {
@@ -107,6 +119,94 @@ func TestLoadConfiguration(t *testing.T) {
 				GmetURL:                DefaultGmetURL,
 				GmetArguments:          metadium.TestnetGmetArguments,
 				SkipGmetAdmin:          true,
+				GasLimitMargin:         DefaultGasLimitMargin,
+				NonceTTL:               DefaultNonceTTL,
+			},
+		},
+		"all set (testnet) + gas limit margin": {
+			Mode:           string(Online),
+			Network:        Testnet,
+			Port:           "1000",
+			GasLimitMargin: "50",
+			cfg: &Configuration{
+				Mode: Online,
+				Network: &types.NetworkIdentifier{
+					Network:    metadium.TestnetNetwork,
+					Blockchain: metadium.Blockchain,
+				},
+				Params:                 params.MetadiumTestnetChainConfig,
+				GenesisBlockIdentifier: metadium.TestnetGenesisBlockIdentifier,
+				Port:                   1000,
+				GmetURL:                DefaultGmetURL,
+				GmetArguments:          metadium.TestnetGmetArguments,
+				GasLimitMargin:         50,
+				NonceTTL:               DefaultNonceTTL,
+			},
+		},
+		"all set (testnet) + nonce ttl": {
+			Mode:     string(Online),
+			Network:  Testnet,
+			Port:     "1000",
+			NonceTTL: "30",
+			cfg: &Configuration{
+				Mode: Online,
+				Network: &types.NetworkIdentifier{
+					Network:    metadium.TestnetNetwork,
+					Blockchain: metadium.Blockchain,
+				},
+				Params:                 params.MetadiumTestnetChainConfig,
+				GenesisBlockIdentifier: metadium.TestnetGenesisBlockIdentifier,
+				Port:                   1000,
+				GmetURL:                DefaultGmetURL,
+				GmetArguments:          metadium.TestnetGmetArguments,
+				GasLimitMargin:         DefaultGasLimitMargin,
+				NonceTTL:               30 * time.Second,
+			},
+		},
+		"all set (testnet) + rebroadcast interval": {
+			Mode:        string(Online),
+			Network:     Testnet,
+			Port:        "1000",
+			Rebroadcast: "60",
+			cfg: &Configuration{
+				Mode: Online,
+				Network: &types.NetworkIdentifier{
+					Network:    metadium.TestnetNetwork,
+					Blockchain: metadium.Blockchain,
+				},
+				Params:                 params.MetadiumTestnetChainConfig,
+				GenesisBlockIdentifier: metadium.TestnetGenesisBlockIdentifier,
+				Port:                   1000,
+				GmetURL:                DefaultGmetURL,
+				GmetArguments:          metadium.TestnetGmetArguments,
+				GasLimitMargin:         DefaultGasLimitMargin,
+				NonceTTL:               DefaultNonceTTL,
+				RebroadcastInterval:    time.Minute,
+			},
+		},
+		"all set (testnet) + caps": {
+			Mode:        string(Online),
+			Network:     Testnet,
+			Port:        "1000",
+			MaxGasPrice: "100000000000",
+			MaxFee:      "10000000000000000000",
+			MaxValue:    "1000000000000000000000",
+			cfg: &Configuration{
+				Mode: Online,
+				Network: &types.NetworkIdentifier{
+					Network:    metadium.TestnetNetwork,
+					Blockchain: metadium.Blockchain,
+				},
+				Params:                 params.MetadiumTestnetChainConfig,
+				GenesisBlockIdentifier: metadium.TestnetGenesisBlockIdentifier,
+				Port:                   1000,
+				GmetURL:                DefaultGmetURL,
+				GmetArguments:          metadium.TestnetGmetArguments,
+				GasLimitMargin:         DefaultGasLimitMargin,
+				NonceTTL:               DefaultNonceTTL,
+				MaxGasPrice:            big.NewInt(100000000000),
+				MaxFee:                 new(big.Int).Mul(big.NewInt(10), big.NewInt(1000000000000000000)),
+				MaxValue:               new(big.Int).Mul(big.NewInt(1000), big.NewInt(1000000000000000000)),
 			},
 		},
 		"invalid mode": {
@@ -121,6 +221,41 @@ func TestLoadConfiguration(t *testing.T) {
 			Port:    "1000",
 			err:     errors.New("bad network is not a valid network"),
 		},
+		"invalid gas limit margin": {
+			Mode:           string(Offline),
+			Network:        Testnet,
+			Port:           "1000",
+			GasLimitMargin: "-1",
+			err:            errors.New("unable to parse GAS_LIMIT_MARGIN -1"),
+		},
+		"invalid nonce ttl": {
+			Mode:     string(Offline),
+			Network:  Testnet,
+			Port:     "1000",
+			NonceTTL: "2m",
+			err:      errors.New("unable to parse NONCE_TTL 2m"),
+		},
+		"invalid rebroadcast interval": {
+			Mode:        string(Offline),
+			Network:     Testnet,
+			Port:        "1000",
+			Rebroadcast: "1m",
+			err:         errors.New("unable to parse REBROADCAST_INTERVAL 1m"),
+		},
+		"invalid max fee": {
+			Mode:    string(Offline),
+			Network: Testnet,
+			Port:    "1000",
+			MaxFee:  "-1",
+			err:     errors.New("unable to parse MAX_FEE -1"),
+		},
+		"invalid max gas price": {
+			Mode:        string(Offline),
+			Network:     Testnet,
+			Port:        "1000",
+			MaxGasPrice: "80 gwei",
+			err:         errors.New("unable to parse MAX_GAS_PRICE 80 gwei"),
+		},
 		"invalid port": {
 			Mode:    string(Offline),
 			Network: Testnet,
@@ -136,6 +271,12 @@ func TestLoadConfiguration(t *testing.T) {
 			os.Setenv(PortEnv, test.Port)
 			os.Setenv(GmetEnv, test.Gmet)
 			os.Setenv(SkipGmetAdminEnv, test.SkipGmetAdmin)
+			os.Setenv(GasLimitMarginEnv, test.GasLimitMargin)
+			os.Setenv(NonceTTLEnv, test.NonceTTL)
+			os.Setenv(MaxGasPriceEnv, test.MaxGasPrice)
+			os.Setenv(MaxFeeEnv, test.MaxFee)
+			os.Setenv(MaxValueEnv, test.MaxValue)
+			os.Setenv(RebroadcastIntervalEnv, test.Rebroadcast)
 
 			cfg, err := LoadConfiguration()
 			if test.err != nil {
@@ -24,16 +24,20 @@ import (
 	"math"
 	"math/big"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	RosettaTypes "github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/consensus/ethash"
 	"github.com/ethereum/go-ethereum/core/types"
 	EthTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/eth/tracers"
 	"github.com/ethereum/go-ethereum/p2p"
 	"github.com/ethereum/go-ethereum/params"
@@ -67,6 +71,10 @@ type Client struct {
 
 	traceSemaphore *semaphore.Weighted
 
+	// tokenCache maps ERC-20 contract addresses to their
+	// *RosettaTypes.Currency (nil if not a token).
+	tokenCache sync.Map
+
 	skipAdminCalls bool
 }
 
@@ -89,7 +97,14 @@ func NewClient(url string, params *params.ChainConfig, skipAdminCalls bool) (*Cl
 		return nil, fmt.Errorf("%w: unable to create GraphQL client", err)
 	}
 
-	return &Client{params, tc, c, g, semaphore.NewWeighted(maxTraceConcurrency), skipAdminCalls}, nil
+	return &Client{
+		p:              params,
+		tc:             tc,
+		c:              c,
+		g:              g,
+		traceSemaphore: semaphore.NewWeighted(maxTraceConcurrency),
+		skipAdminCalls: skipAdminCalls,
+	}, nil
 }
 
 // Close shuts down the RPC client connection.
@@ -106,7 +121,7 @@ func (ec *Client) Status(ctx context.Context) (
 	[]*RosettaTypes.Peer,
 	error,
 ) {
-	header, err := ec.blockHeader(ctx, nil)
+	header, err := ec.HeaderByNumber(ctx, nil)
 	if err != nil {
 		return nil, -1, nil, nil, err
 	}
@@ -160,6 +175,143 @@ func (ec *Client) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
 	return (*big.Int)(&hex), nil
 }
 
+// SuggestGasTipCap retrieves the currently suggested gas tip cap after 1559 to
+// allow a timely execution of a transaction.
+func (ec *Client) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	var hex hexutil.Big
+	if err := ec.c.CallContext(ctx, &hex, "eth_maxPriorityFeePerGas"); err != nil {
+		return nil, err
+	}
+	return (*big.Int)(&hex), nil
+}
+
+// FeeHistory is the fee market history of a range of blocks.
+type FeeHistory struct {
+	OldestBlock *big.Int
+
+	// Reward holds, for each block, the effective priority fee
+	// per gas paid at each requested percentile of the gas used
+	// in the block. Before London, this is the gas price.
+	Reward       [][]*big.Int
+	BaseFee      []*big.Int
+	GasUsedRatio []float64
+}
+
+// feeHistoryResult is the response of eth_feeHistory.
+type feeHistoryResult struct {
+	OldestBlock  *hexutil.Big     `json:"oldestBlock"`
+	Reward       [][]*hexutil.Big `json:"reward,omitempty"`
+	BaseFee      []*hexutil.Big   `json:"baseFeePerGas,omitempty"`
+	GasUsedRatio []float64        `json:"gasUsedRatio"`
+}
+
+// FeeHistory returns the fee market history of the latest blockCount
+// blocks, with the priority fees paid at rewardPercentiles.
+func (ec *Client) FeeHistory(
+	ctx context.Context,
+	blockCount uint64,
+	rewardPercentiles []float64,
+) (*FeeHistory, error) {
+	var result feeHistoryResult
+	if err := ec.c.CallContext(
+		ctx,
+		&result,
+		"eth_feeHistory",
+		hexutil.Uint64(blockCount),
+		"latest",
+		rewardPercentiles,
+	); err != nil {
+		return nil, err
+	}
+
+	if result.OldestBlock == nil {
+		return nil, errors.New("fee history is missing the oldest block")
+	}
+
+	history := &FeeHistory{
+		OldestBlock:  result.OldestBlock.ToInt(),
+		Reward:       make([][]*big.Int, len(result.Reward)),
+		BaseFee:      make([]*big.Int, len(result.BaseFee)),
+		GasUsedRatio: result.GasUsedRatio,
+	}
+	for i, rewards := range result.Reward {
+		history.Reward[i] = make([]*big.Int, len(rewards))
+		for j, reward := range rewards {
+			history.Reward[i][j] = reward.ToInt()
+		}
+	}
+	for i, baseFee := range result.BaseFee {
+		history.BaseFee[i] = baseFee.ToInt()
+	}
+
+	return history, nil
+}
+
+// EstimateGas tries to estimate the gas needed to execute a specific transaction based on
+// the current pending state of the backend blockchain.
+func (ec *Client) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	var hex hexutil.Uint64
+	if err := ec.c.CallContext(ctx, &hex, "eth_estimateGas", toCallArg(msg)); err != nil {
+		return 0, err
+	}
+	return uint64(hex), nil
+}
+
+// accessListResult is the response of eth_createAccessList.
+type accessListResult struct {
+	AccessList *EthTypes.AccessList `json:"accessList"`
+	Error      string               `json:"error,omitempty"`
+}
+
+// CreateAccessList returns the EIP-2930 access list of the
+// addresses and storage slots msg accesses when executed
+// on top of the pending block.
+func (ec *Client) CreateAccessList(ctx context.Context, msg ethereum.CallMsg) (EthTypes.AccessList, error) {
+	var result accessListResult
+	if err := ec.c.CallContext(ctx, &result, "eth_createAccessList", toCallArg(msg), "pending"); err != nil {
+		return nil, err
+	}
+
+	if len(result.Error) > 0 {
+		return nil, fmt.Errorf("unable to create access list: %s", result.Error)
+	}
+
+	if result.AccessList == nil {
+		return EthTypes.AccessList{}, nil
+	}
+
+	return *result.AccessList, nil
+}
+
+func toCallArg(msg ethereum.CallMsg) interface{} {
+	arg := map[string]interface{}{
+		"from": msg.From,
+		"to":   msg.To,
+	}
+	if len(msg.Data) > 0 {
+		arg["data"] = hexutil.Bytes(msg.Data)
+	}
+	if msg.Value != nil {
+		arg["value"] = (*hexutil.Big)(msg.Value)
+	}
+	if msg.Gas != 0 {
+		arg["gas"] = hexutil.Uint64(msg.Gas)
+	}
+	if msg.GasPrice != nil {
+		arg["gasPrice"] = (*hexutil.Big)(msg.GasPrice)
+	}
+	if msg.GasFeeCap != nil {
+		arg["maxFeePerGas"] = (*hexutil.Big)(msg.GasFeeCap)
+	}
+	if msg.GasTipCap != nil {
+		arg["maxPriorityFeePerGas"] = (*hexutil.Big)(msg.GasTipCap)
+	}
+	if len(msg.AccessList) > 0 {
+		arg["accessList"] = msg.AccessList
+	}
+	return arg
+}
+
 // Peers retrieves all peers of the node.
 func (ec *Client) peers(ctx context.Context) ([]*RosettaTypes.Peer, error) {
 	var info []*p2p.PeerInfo
@@ -198,7 +350,14 @@ func (ec *Client) SendTransaction(ctx context.Context, tx *types.Transaction) er
 	if err != nil {
 		return err
 	}
-	return ec.c.CallContext(ctx, nil, "eth_sendRawTransaction", hexutil.Encode(data))
+	return ec.SendRawTransaction(ctx, data)
+}
+
+// SendRawTransaction injects an encoded signed transaction into the pending
+// pool for execution. It is used for transaction types that go-ethereum
+// cannot encode, such as fee-delegated transactions.
+func (ec *Client) SendRawTransaction(ctx context.Context, rawTx []byte) error {
+	return ec.c.CallContext(ctx, nil, "eth_sendRawTransaction", hexutil.Encode(rawTx))
 }
 
 func toBlockNumArg(number *big.Int) string {
@@ -238,9 +397,9 @@ func (ec *Client) Block(
 	return ec.getParsedBlock(ctx, "eth_getBlockByNumber", toBlockNumArg(nil), true)
 }
 
-// Header returns a block header from the current canonical chain. If number is
+// HeaderByNumber returns a block header from the current canonical chain. If number is
 // nil, the latest known header is returned.
-func (ec *Client) blockHeader(ctx context.Context, number *big.Int) (*types.Header, error) {
+func (ec *Client) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
 	var head *types.Header
 	err := ec.c.CallContext(ctx, &head, "eth_getBlockByNumber", toBlockNumArg(number), false)
 	if err == nil && head == nil {
@@ -378,22 +537,10 @@ func (ec *Client) getBlock(
 	loadedTxs := make([]*loadedTransaction, len(body.Transactions))
 	for i, tx := range body.Transactions {
 		txs[i] = tx.tx
-		receipt := receipts[i]
-		gasUsedBig := new(big.Int).SetUint64(receipt.GasUsed)
-		feeAmount := gasUsedBig.Mul(gasUsedBig, txs[i].GasPrice())
-
-		loadedTxs[i] = tx.LoadedTransaction()
-		loadedTxs[i].Transaction = txs[i]
-
-		feeAmount, feeBurned, err := calculateGas(txs[i], receipt, head)
+		loadedTxs[i], err = ec.loadTransaction(ctx, &head, &body.Transactions[i], receipts[i])
 		if err != nil {
 			return nil, nil, err
 		}
-		loadedTxs[i].FeeAmount = feeAmount
-		loadedTxs[i].FeeBurned = feeBurned
-
-		loadedTxs[i].Miner = MustChecksum(head.Coinbase.Hex())
-		loadedTxs[i].Receipt = receipt
 
 		// Continue if calls does not exist (occurs at genesis)
 		if !addTraces {
@@ -407,6 +554,35 @@ func (ec *Client) getBlock(
 	return types.NewBlockWithHeader(&head).WithBody(txs, uncles), loadedTxs, nil
 }
 
+// loadTransaction computes the fees and token currencies of
+// a transaction included in the block with header head.
+func (ec *Client) loadTransaction(
+	ctx context.Context,
+	head *types.Header,
+	tx *rpcTransaction,
+	receipt *Receipt,
+) (*loadedTransaction, error) {
+	loadedTx := tx.LoadedTransaction()
+
+	feeAmount, feeBurned, err := calculateGas(tx.tx, receipt, *head)
+	if err != nil {
+		return nil, err
+	}
+	loadedTx.FeeAmount = feeAmount
+	loadedTx.FeeBurned = feeBurned
+
+	loadedTx.Miner = MustChecksum(head.Coinbase.Hex())
+	loadedTx.Receipt = receipt
+
+	tokenCurrencies, err := ec.tokenCurrencies(ctx, receipt.Logs, head.Number)
+	if err != nil {
+		return nil, err
+	}
+	loadedTx.TokenCurrencies = tokenCurrencies
+
+	return loadedTx, nil
+}
+
 func (ec *Client) getBlockTraces(
 	ctx context.Context,
 	blockHash common.Hash,
@@ -437,57 +613,74 @@ func (ec *Client) getBlockTraces(
 	return calls, rawCalls, nil
 }
 
+// Receipt is the receipt of a transaction as returned
+// by gmet.
 type Receipt struct {
-	Type              uint8  `json:"type,omitempty"`
-	PostState         []byte `json:"root"`
-	Status            uint64 `json:"status"`
-	CumulativeGasUsed uint64 `json:"cumulativeGasUsed" gencodec:"required"`
+	Type              uint8        `json:"type,omitempty"`
+	PostState         []byte       `json:"root"`
+	Status            uint64       `json:"status"`
+	CumulativeGasUsed uint64       `json:"cumulativeGasUsed" gencodec:"required"`
+	Bloom             types.Bloom  `json:"logsBloom"`
+	Logs              []*types.Log `json:"logs"`
 
 	TxHash          common.Hash    `json:"transactionHash" gencodec:"required"`
 	ContractAddress common.Address `json:"contractAddress"`
 	GasUsed         uint64         `json:"gasUsed" gencodec:"required"`
 
 	BlockHash        common.Hash `json:"blockHash,omitempty"`
+	BlockNumber      *big.Int    `json:"blockNumber,omitempty"`
 	TransactionIndex uint        `json:"transactionIndex"`
 }
 
+// MarshalJSON marshals as JSON.
 func (r Receipt) MarshalJSON() ([]byte, error) {
 	type Receipt struct {
 		Type              hexutil.Uint64 `json:"type,omitempty"`
 		PostState         hexutil.Bytes  `json:"root"`
 		Status            hexutil.Uint64 `json:"status"`
 		CumulativeGasUsed hexutil.Uint64 `json:"cumulativeGasUsed" gencodec:"required"`
+		Bloom             types.Bloom    `json:"logsBloom"`
+		Logs              []*types.Log   `json:"logs"`
 		TxHash            common.Hash    `json:"transactionHash" gencodec:"required"`
 		ContractAddress   common.Address `json:"contractAddress"`
 		GasUsed           hexutil.Uint64 `json:"gasUsed" gencodec:"required"`
 		BlockHash         common.Hash    `json:"blockHash,omitempty"`
-		// BlockNumber       *hexutil.Big   `json:"blockNumber,omitempty"`
-		TransactionIndex hexutil.Uint `json:"transactionIndex"`
+		BlockNumber       *hexutil.Big   `json:"blockNumber,omitempty"`
+		TransactionIndex  hexutil.Uint   `json:"transactionIndex"`
 	}
 	var enc Receipt
 	enc.Type = hexutil.Uint64(r.Type)
 	enc.PostState = r.PostState
 	enc.Status = hexutil.Uint64(r.Status)
 	enc.CumulativeGasUsed = hexutil.Uint64(r.CumulativeGasUsed)
+	enc.Bloom = r.Bloom
+	enc.Logs = r.Logs
+	if enc.Logs == nil {
+		enc.Logs = []*types.Log{}
+	}
 	enc.TxHash = r.TxHash
 	enc.ContractAddress = r.ContractAddress
 	enc.GasUsed = hexutil.Uint64(r.GasUsed)
 	enc.BlockHash = r.BlockHash
-	// enc.BlockNumber = (*hexutil.Big)(r.BlockNumber)
+	enc.BlockNumber = (*hexutil.Big)(r.BlockNumber)
 	enc.TransactionIndex = hexutil.Uint(r.TransactionIndex)
 	return json.Marshal(&enc)
 }
 
+// UnmarshalJSON unmarshals from JSON.
 func (r *Receipt) UnmarshalJSON(input []byte) error {
 	type Receipt struct {
 		Type              *hexutil.Uint64 `json:"type,omitempty"`
 		PostState         *hexutil.Bytes  `json:"root"`
 		Status            *hexutil.Uint64 `json:"status"`
 		CumulativeGasUsed *hexutil.Uint64 `json:"cumulativeGasUsed" gencodec:"required"`
+		Bloom             *types.Bloom    `json:"logsBloom"`
+		Logs              []*types.Log    `json:"logs"`
 		TxHash            *common.Hash    `json:"transactionHash" gencodec:"required"`
 		ContractAddress   *common.Address `json:"contractAddress"`
 		GasUsed           *hexutil.Uint64 `json:"gasUsed" gencodec:"required"`
 		BlockHash         *common.Hash    `json:"blockHash,omitempty"`
+		BlockNumber       *hexutil.Big    `json:"blockNumber,omitempty"`
 		TransactionIndex  *hexutil.Uint   `json:"transactionIndex"`
 	}
 	var dec Receipt
@@ -507,6 +700,10 @@ func (r *Receipt) UnmarshalJSON(input []byte) error {
 		return errors.New("missing required field 'cumulativeGasUsed' for Receipt")
 	}
 	r.CumulativeGasUsed = uint64(*dec.CumulativeGasUsed)
+	if dec.Bloom != nil {
+		r.Bloom = *dec.Bloom
+	}
+	r.Logs = dec.Logs
 	if dec.TxHash == nil {
 		return errors.New("missing required field 'transactionHash' for Receipt")
 	}
@@ -521,9 +718,9 @@ func (r *Receipt) UnmarshalJSON(input []byte) error {
 	if dec.BlockHash != nil {
 		r.BlockHash = *dec.BlockHash
 	}
-	//if dec.BlockNumber != nil {
-	//	r.BlockNumber = (*big.Int)(dec.BlockNumber)
-	//}
+	if dec.BlockNumber != nil {
+		r.BlockNumber = (*big.Int)(dec.BlockNumber)
+	}
 	if dec.TransactionIndex != nil {
 		r.TransactionIndex = uint(*dec.TransactionIndex)
 	}
@@ -555,6 +752,84 @@ func (ec *Client) getBlockReceipts(
 	return receipts, err
 }
 
+func (ec *Client) getTransactionTrace(
+	ctx context.Context,
+	txHash common.Hash,
+) (*Call, json.RawMessage, error) {
+	if err := ec.traceSemaphore.Acquire(ctx, semaphoreTraceWeight); err != nil {
+		return nil, nil, err
+	}
+	defer ec.traceSemaphore.Release(semaphoreTraceWeight)
+
+	var call *Call
+	var raw json.RawMessage
+	err := ec.c.CallContext(ctx, &raw, "debug_traceTransaction", txHash, ec.tc)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := json.Unmarshal(raw, &call); err != nil {
+		return nil, nil, err
+	}
+
+	return call, raw, nil
+}
+
+// DryRun is the outcome of executing a transaction
+// on top of the latest block without broadcasting it.
+type DryRun struct {
+	Operations   []*RosettaTypes.Operation `json:"operations"`
+	GasUsed      uint64                    `json:"gas_used"`
+	Reverted     bool                      `json:"reverted"`
+	RevertReason string                    `json:"revert_reason,omitempty"`
+}
+
+// DryRun traces the execution of msg on top of the latest block
+// and returns the operations it would cause. Fees are not
+// included in the operations.
+func (ec *Client) DryRun(ctx context.Context, msg ethereum.CallMsg) (*DryRun, error) {
+	if err := ec.traceSemaphore.Acquire(ctx, semaphoreTraceWeight); err != nil {
+		return nil, err
+	}
+	defer ec.traceSemaphore.Release(semaphoreTraceWeight)
+
+	var raw json.RawMessage
+	err := ec.c.CallContext(ctx, &raw, "debug_traceCall", toCallArg(msg), "latest", ec.tc)
+	if err != nil {
+		return nil, err
+	}
+
+	var call *Call
+	if err := json.Unmarshal(raw, &call); err != nil {
+		return nil, err
+	}
+
+	dryRun := &DryRun{
+		Operations: traceOps(flattenTraces(call, []*flatCall{}), 0),
+		GasUsed:    call.GasUsed.Uint64(),
+		Reverted:   call.Revert,
+	}
+	if !call.Revert {
+		return dryRun, nil
+	}
+
+	// The tracer only keeps the output of a call
+	// reverted with a reason.
+	var output struct {
+		Output hexutil.Bytes `json:"output"`
+	}
+	if err := json.Unmarshal(raw, &output); err != nil {
+		return nil, err
+	}
+
+	dryRun.RevertReason = call.ErrorMessage
+	if reason, err := abi.UnpackRevert(output.Output); err == nil {
+		dryRun.RevertReason = reason
+	}
+
+	return dryRun, nil
+}
+
 type rpcCall struct {
 	Result *Call `json:"result"`
 }
@@ -824,11 +1099,27 @@ type txExtraInfo struct {
 type rpcTransaction struct {
 	tx *types.Transaction
 	txExtraInfo
+
+	// hash is stored separately because the hash of a
+	// fee-delegated transaction is not the hash of tx.
+	hash     common.Hash
+	feePayer *common.Address
 }
 
 func (tx *rpcTransaction) UnmarshalJSON(msg []byte) error {
-	if err := json.Unmarshal(msg, &tx.tx); err != nil {
-		return err
+	if IsFeeDelegated(msg) {
+		var feeDelegatedTx FeeDelegatedTransaction
+		if err := json.Unmarshal(msg, &feeDelegatedTx); err != nil {
+			return err
+		}
+		tx.tx = feeDelegatedTx.SenderTx
+		tx.hash = feeDelegatedTx.Hash()
+		tx.feePayer = &feeDelegatedTx.FeePayer
+	} else {
+		if err := json.Unmarshal(msg, &tx.tx); err != nil {
+			return err
+		}
+		tx.hash = tx.tx.Hash()
 	}
 	return json.Unmarshal(msg, &tx.txExtraInfo)
 }
@@ -836,7 +1127,9 @@ func (tx *rpcTransaction) UnmarshalJSON(msg []byte) error {
 func (tx *rpcTransaction) LoadedTransaction() *loadedTransaction {
 	ethTx := &loadedTransaction{
 		Transaction: tx.tx,
+		Hash:        tx.hash,
 		From:        tx.txExtraInfo.From,
+		FeePayer:    tx.feePayer,
 		BlockNumber: tx.txExtraInfo.BlockNumber,
 		BlockHash:   tx.txExtraInfo.BlockHash,
 	}
@@ -844,8 +1137,12 @@ func (tx *rpcTransaction) LoadedTransaction() *loadedTransaction {
 }
 
 type loadedTransaction struct {
+	// Transaction is the sender transaction
+	// of a fee-delegated transaction.
 	Transaction *types.Transaction
+	Hash        common.Hash
 	From        *common.Address
+	FeePayer    *common.Address // nil if the transaction is not fee delegated
 	BlockNumber *string
 	BlockHash   *common.Hash
 	FeeAmount   *big.Int
@@ -856,9 +1153,20 @@ type loadedTransaction struct {
 	Trace    *Call
 	RawTrace json.RawMessage
 	Receipt  *Receipt
+
+	// TokenCurrencies are the currencies of the ERC-20
+	// contracts that emitted Transfer events.
+	TokenCurrencies map[common.Address]*RosettaTypes.Currency
 }
 
 func feeOps(tx *loadedTransaction) []*RosettaTypes.Operation {
+	// The fee of a fee-delegated transaction is
+	// charged to the fee payer instead of the sender.
+	payer := tx.From
+	if tx.FeePayer != nil {
+		payer = tx.FeePayer
+	}
+
 	return []*RosettaTypes.Operation{
 		{
 			OperationIdentifier: &RosettaTypes.OperationIdentifier{
@@ -867,7 +1175,7 @@ func feeOps(tx *loadedTransaction) []*RosettaTypes.Operation {
 			Type:   FeeOpType,
 			Status: RosettaTypes.String(SuccessStatus),
 			Account: &RosettaTypes.AccountIdentifier{
-				Address: MustChecksum(tx.From.String()),
+				Address: MustChecksum(payer.String()),
 			},
 			Amount: &RosettaTypes.Amount{
 				Value:    new(big.Int).Neg(tx.FeeAmount).String(),
@@ -913,6 +1221,12 @@ func effectiveGasPrice(tx *EthTypes.Transaction, baseFee *big.Int) (*big.Int, er
 	return new(big.Int).Add(tip, baseFee), nil
 }
 
+// TransactionReceipt returns the receipt of a mined transaction.
+// It returns ethereum.NotFound if the transaction is not mined.
+func (ec *Client) TransactionReceipt(ctx context.Context, txHash common.Hash) (*Receipt, error) {
+	return ec.transactionReceipt(ctx, txHash)
+}
+
 // transactionReceipt returns the receipt of a transaction by transaction hash.
 // Note that the receipt is not available for pending transactions.
 func (ec *Client) transactionReceipt(
@@ -1095,6 +1409,193 @@ func (ec *Client) getParsedBlock(
 	}, nil
 }
 
+// Transaction returns the populated transaction with the hash in
+// transactionIdentifier, included in the block at blockIdentifier.
+//
+// Unlike Block, only the requested transaction is traced.
+func (ec *Client) Transaction(
+	ctx context.Context,
+	blockIdentifier *RosettaTypes.BlockIdentifier,
+	transactionIdentifier *RosettaTypes.TransactionIdentifier,
+) (*RosettaTypes.Transaction, error) {
+	var head *types.Header
+	err := ec.c.CallContext(ctx, &head, "eth_getBlockByHash", blockIdentifier.Hash, false)
+	if err != nil {
+		return nil, fmt.Errorf("%w: block fetch failed", err)
+	}
+	if head == nil || head.Number.Int64() != blockIdentifier.Index {
+		return nil, fmt.Errorf("%w: %s", ErrBlockOrphaned, blockIdentifier.Hash)
+	}
+
+	txHash := common.HexToHash(transactionIdentifier.Hash)
+	var tx *rpcTransaction
+	err = ec.c.CallContext(ctx, &tx, "eth_getTransactionByHash", txHash)
+	if err != nil {
+		return nil, fmt.Errorf("%w: transaction fetch failed", err)
+	}
+	if tx == nil || tx.BlockHash == nil || *tx.BlockHash != common.HexToHash(blockIdentifier.Hash) {
+		return nil, fmt.Errorf(
+			"%w: %s is not in block %s",
+			ErrTransactionNotFound,
+			transactionIdentifier.Hash,
+			blockIdentifier.Hash,
+		)
+	}
+
+	receipt, err := ec.transactionReceipt(ctx, txHash)
+	if err != nil {
+		return nil, fmt.Errorf("%w: could not get receipt for %s", err, transactionIdentifier.Hash)
+	}
+
+	loadedTx, err := ec.loadTransaction(ctx, head, tx, receipt)
+	if err != nil {
+		return nil, err
+	}
+
+	loadedTx.Trace, loadedTx.RawTrace, err = ec.getTransactionTrace(ctx, txHash)
+	if err != nil {
+		return nil, fmt.Errorf("%w: could not get trace for %s", err, transactionIdentifier.Hash)
+	}
+
+	return ec.populateTransaction(loadedTx)
+}
+
+// txPoolContentResponse is the response of txpool_content. Transactions
+// are keyed by sender address and then by nonce.
+type txPoolContentResponse struct {
+	Pending map[string]map[string]*txPoolTransaction `json:"pending"`
+	Queued  map[string]map[string]*txPoolTransaction `json:"queued"`
+}
+
+type txPoolTransaction struct {
+	Hash common.Hash `json:"hash"`
+}
+
+// Mempool returns the hashes of all pending and queued
+// transactions in the txpool of gmet.
+func (ec *Client) Mempool(ctx context.Context) ([]*RosettaTypes.TransactionIdentifier, error) {
+	var content txPoolContentResponse
+	if err := ec.c.CallContext(ctx, &content, "txpool_content"); err != nil {
+		return nil, err
+	}
+
+	identifiers := []*RosettaTypes.TransactionIdentifier{}
+	for _, pool := range []map[string]map[string]*txPoolTransaction{content.Pending, content.Queued} {
+		for _, txs := range pool {
+			for _, tx := range txs {
+				identifiers = append(identifiers, &RosettaTypes.TransactionIdentifier{
+					Hash: tx.Hash.Hex(),
+				})
+			}
+		}
+	}
+
+	// Map iteration order is random, so we sort the
+	// identifiers to return a stable response.
+	sort.Slice(identifiers, func(i, j int) bool {
+		return identifiers[i].Hash < identifiers[j].Hash
+	})
+
+	return identifiers, nil
+}
+
+// txPoolTransactionsResponse is the response of txpool_content
+// with the transactions fully decoded.
+type txPoolTransactionsResponse struct {
+	Pending map[string]map[string]*rpcTransaction `json:"pending"`
+	Queued  map[string]map[string]*rpcTransaction `json:"queued"`
+}
+
+// PendingTransaction returns the transaction sent by from with the
+// provided nonce from the txpool of gmet, and its hash. For
+// fee-delegated transactions, the sender transaction is returned
+// with the hash of the fee-delegated transaction.
+func (ec *Client) PendingTransaction(
+	ctx context.Context,
+	from common.Address,
+	nonce uint64,
+) (*types.Transaction, common.Hash, error) {
+	var content txPoolTransactionsResponse
+	if err := ec.c.CallContext(ctx, &content, "txpool_content"); err != nil {
+		return nil, common.Hash{}, err
+	}
+
+	key := strconv.FormatUint(nonce, 10) // nolint:gomnd
+	for _, pool := range []map[string]map[string]*rpcTransaction{content.Pending, content.Queued} {
+		for account, txs := range pool {
+			if common.HexToAddress(account) != from {
+				continue
+			}
+
+			if tx, ok := txs[key]; ok {
+				return tx.tx, tx.hash, nil
+			}
+		}
+	}
+
+	return nil, common.Hash{}, fmt.Errorf(
+		"%w: no pending transaction from %s with nonce %d",
+		ErrTransactionNotFound,
+		from.Hex(),
+		nonce,
+	)
+}
+
+// MempoolTransaction returns the predicted operations of a transaction
+// in the txpool: the maximum fee it can be charged and its top-level
+// value transfer. Internal transfers are only known once the transaction
+// is executed, so they are not included.
+func (ec *Client) MempoolTransaction(
+	ctx context.Context,
+	transactionIdentifier *RosettaTypes.TransactionIdentifier,
+) (*RosettaTypes.Transaction, error) {
+	txHash := common.HexToHash(transactionIdentifier.Hash)
+	var tx *rpcTransaction
+	err := ec.c.CallContext(ctx, &tx, "eth_getTransactionByHash", txHash)
+	if err != nil {
+		return nil, fmt.Errorf("%w: transaction fetch failed", err)
+	}
+	if tx == nil || tx.BlockHash != nil {
+		return nil, fmt.Errorf(
+			"%w: %s is not in the mempool",
+			ErrTransactionNotFound,
+			transactionIdentifier.Hash,
+		)
+	}
+
+	loadedTx := tx.LoadedTransaction()
+	loadedTx.FeeAmount = new(big.Int).Mul(
+		new(big.Int).SetUint64(loadedTx.Transaction.Gas()),
+		loadedTx.Transaction.GasPrice(),
+	)
+
+	transfer := &flatCall{
+		Type:  CallOpType,
+		From:  *loadedTx.From,
+		Value: loadedTx.Transaction.Value(),
+	}
+	if to := loadedTx.Transaction.To(); to != nil {
+		transfer.To = *to
+	} else {
+		transfer.Type = CreateOpType
+		transfer.To = crypto.CreateAddress(*loadedTx.From, loadedTx.Transaction.Nonce())
+	}
+
+	ops := feeOps(loadedTx)
+	ops = append(ops, traceOps([]*flatCall{transfer}, len(ops))...)
+
+	return &RosettaTypes.Transaction{
+		TransactionIdentifier: &RosettaTypes.TransactionIdentifier{
+			Hash: loadedTx.Hash.Hex(),
+		},
+		Operations: ops,
+		Metadata: map[string]interface{}{
+			"gas_limit": hexutil.EncodeUint64(loadedTx.Transaction.Gas()),
+			"gas_price": hexutil.EncodeBig(loadedTx.Transaction.GasPrice()),
+		},
+	}, nil
+}
+
 func convertTime(time uint64) int64 {
 	return int64(time) * 1000
 }
@@ -1137,7 +1638,7 @@ func (ec *Client) populateTransactions(
 			tx,
 		)
 		if err != nil {
-			return nil, fmt.Errorf("%w: cannot parse %s", err, tx.Transaction.Hash().Hex())
+			return nil, fmt.Errorf("%w: cannot parse %s", err, tx.Hash.Hex())
 		}
 
 		transactions = append(transactions, transaction)
@@ -1160,6 +1661,10 @@ func (ec *Client) populateTransaction(
 	traceOps := traceOps(traces, len(ops))
 	ops = append(ops, traceOps...)
 
+	// Compute ERC-20 transfer operations
+	tokenOps := erc20Ops(tx.Receipt, tx.TokenCurrencies, len(ops))
+	ops = append(ops, tokenOps...)
+
 	// Marshal receipt and trace data
 	// TODO: replace with marshalJSONMap (used in `services`)
 	receiptBytes, err := tx.Receipt.MarshalJSON()
@@ -1179,7 +1684,7 @@ func (ec *Client) populateTransaction(
 
 	populatedTransaction := &RosettaTypes.Transaction{
 		TransactionIdentifier: &RosettaTypes.TransactionIdentifier{
-			Hash: tx.Transaction.Hash().Hex(),
+			Hash: tx.Hash.Hex(),
 		},
 		Operations: ops,
 		Metadata: map[string]interface{}{
@@ -1424,6 +1929,7 @@ func (ec *Client) Balance(
 	ctx context.Context,
 	account *RosettaTypes.AccountIdentifier,
 	block *RosettaTypes.PartialBlockIdentifier,
+	currencies []*RosettaTypes.Currency,
 ) (*RosettaTypes.AccountBalanceResponse, error) {
 	// log.Printf("request Balance Address: %s\n", account.Address)
 
@@ -1480,13 +1986,45 @@ func (ec *Client) Balance(
 	*/
 	balance := bal.Data.Block.Account.Balance
 	nonce := bal.Data.Block.Account.Nonce
-	return &RosettaTypes.AccountBalanceResponse{
-		Balances: []*RosettaTypes.Amount{
-			{
+
+	// Token balances are read at the block of the META
+	// balance, so all balances are at the same block.
+	if len(currencies) == 0 {
+		currencies = []*RosettaTypes.Currency{Currency}
+	}
+	balances := make([]*RosettaTypes.Amount, len(currencies))
+	for i, currency := range currencies {
+		if IsNativeCurrency(currency) {
+			balances[i] = &RosettaTypes.Amount{
 				Value:    balance.String(),
 				Currency: Currency,
-			},
-		},
+			}
+			continue
+		}
+
+		contractAddress, ok := TokenContractAddress(currency)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedCurrency, RosettaTypes.PrintStruct(currency))
+		}
+
+		tokenBalance, err := ec.tokenBalance(
+			ctx,
+			common.HexToAddress(contractAddress),
+			common.HexToAddress(account.Address),
+			big.NewInt(bal.Data.Block.Number),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		balances[i] = &RosettaTypes.Amount{
+			Value:    tokenBalance.String(),
+			Currency: currency,
+		}
+	}
+
+	return &RosettaTypes.AccountBalanceResponse{
+		Balances: balances,
 		BlockIdentifier: &RosettaTypes.BlockIdentifier{
 			Hash:  bal.Data.Block.Hash,
 			Index: bal.Data.Block.Number,
@@ -34,7 +34,6 @@ import (
 	"github.com/ethereum/go-ethereum/eth/tracers"
 	"github.com/ethereum/go-ethereum/p2p"
 	"github.com/ethereum/go-ethereum/params"
-	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"golang.org/x/sync/semaphore"
@@ -561,6 +560,7 @@ func TestBalance(t *testing.T) {
 			Address: "0x098cE27428a8fe633f1177f8253Ea789894d8aDf",
 		},
 		nil,
+		nil,
 	)
 	assert.Equal(t, &RosettaTypes.AccountBalanceResponse{
 		BlockIdentifier: &RosettaTypes.BlockIdentifier{
@@ -584,6 +584,134 @@ func TestBalance(t *testing.T) {
 	mockGraphQL.AssertExpectations(t)
 }
 
+func TestBalance_Tokens(t *testing.T) {
+	mockJSONRPC := &mocks.JSONRPC{}
+	mockGraphQL := &mocks.GraphQL{}
+
+	c := &Client{
+		c:              mockJSONRPC,
+		g:              mockGraphQL,
+		traceSemaphore: semaphore.NewWeighted(100),
+	}
+
+	ctx := context.Background()
+	result, err := ioutil.ReadFile(
+		"testdata/account_balance_0x098cE27428a8fe633f1177f8253Ea789894d8aDf.json",
+	)
+	assert.NoError(t, err)
+	account := &RosettaTypes.AccountIdentifier{
+		Address: "0x098cE27428a8fe633f1177f8253Ea789894d8aDf",
+	}
+	mockGraphQL.On(
+		"Query",
+		ctx,
+		`{
+			block(){
+				hash
+				number
+				account(address:"0x098cE27428a8fe633f1177f8253Ea789894d8aDf"){
+					balance
+					transactionCount
+					code
+				}
+			}
+		}`,
+	).Return(
+		string(result),
+		nil,
+	)
+
+	token := common.HexToAddress("0x2d74530C0C196De44d3906822053bf336F18a16e")
+	tokenCurrency := &RosettaTypes.Currency{
+		Symbol:   "TKN",
+		Decimals: 18,
+		Metadata: map[string]interface{}{
+			ContractAddressKey: token.Hex(),
+		},
+	}
+	balanceOfCall := map[string]string{
+		"to": token.Hex(),
+		"data": hexutil.Encode(append(
+			append([]byte{}, balanceOfMethodID...),
+			common.LeftPadBytes(common.HexToAddress(account.Address).Bytes(), 32)...,
+		)),
+	}
+
+	// Token balances are read at the block of the META balance
+	mockJSONRPC.On(
+		"CallContext",
+		ctx,
+		mock.Anything,
+		"eth_call",
+		balanceOfCall,
+		hexutil.EncodeBig(big.NewInt(19388485)),
+	).Return(
+		nil,
+	).Run(
+		func(args mock.Arguments) {
+			r := args.Get(1).(*hexutil.Bytes)
+
+			*r = common.LeftPadBytes(big.NewInt(5000).Bytes(), 32)
+		},
+	).Once()
+	resp, err := c.Balance(
+		ctx,
+		account,
+		nil,
+		[]*RosettaTypes.Currency{tokenCurrency, Currency},
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, []*RosettaTypes.Amount{
+		{
+			Value:    "5000",
+			Currency: tokenCurrency,
+		},
+		{
+			Value:    "1390630720000000000",
+			Currency: Currency,
+		},
+	}, resp.Balances)
+
+	// Contracts without balanceOf are not tokens
+	mockJSONRPC.On(
+		"CallContext",
+		ctx,
+		mock.Anything,
+		"eth_call",
+		balanceOfCall,
+		hexutil.EncodeBig(big.NewInt(19388485)),
+	).Return(
+		&rpcError{message: "execution reverted"},
+	).Once()
+	resp, err = c.Balance(ctx, account, nil, []*RosettaTypes.Currency{tokenCurrency})
+	assert.Nil(t, resp)
+	assert.True(t, errors.Is(err, ErrUnsupportedCurrency))
+
+	// Other call errors are returned as is
+	mockJSONRPC.On(
+		"CallContext",
+		ctx,
+		mock.Anything,
+		"eth_call",
+		balanceOfCall,
+		hexutil.EncodeBig(big.NewInt(19388485)),
+	).Return(
+		&rpcError{message: "missing trie node 0x1234 (path )"},
+	).Once()
+	resp, err = c.Balance(ctx, account, nil, []*RosettaTypes.Currency{tokenCurrency})
+	assert.Nil(t, resp)
+	assert.False(t, errors.Is(err, ErrUnsupportedCurrency))
+	assert.Error(t, err)
+
+	// Currencies without contract address are not supported
+	resp, err = c.Balance(ctx, account, nil, []*RosettaTypes.Currency{{Symbol: "TKN", Decimals: 18}})
+	assert.Nil(t, resp)
+	assert.True(t, errors.Is(err, ErrUnsupportedCurrency))
+
+	mockJSONRPC.AssertExpectations(t)
+	mockGraphQL.AssertExpectations(t)
+}
+
 func TestBalance_Historical_Hash(t *testing.T) {
 	mockJSONRPC := &mocks.JSONRPC{}
 	mockGraphQL := &mocks.GraphQL{}
@@ -629,6 +757,7 @@ func TestBalance_Historical_Hash(t *testing.T) {
 			),
 			Index: RosettaTypes.Int64(19388485),
 		},
+		nil,
 	)
 	assert.Equal(t, &RosettaTypes.AccountBalanceResponse{
 		BlockIdentifier: &RosettaTypes.BlockIdentifier{
@@ -694,6 +823,7 @@ func TestBalance_Historical_Index(t *testing.T) {
 		&RosettaTypes.PartialBlockIdentifier{
 			Index: RosettaTypes.Int64(19388485),
 		},
+		nil,
 	)
 	assert.Equal(t, &RosettaTypes.AccountBalanceResponse{
 		BlockIdentifier: &RosettaTypes.BlockIdentifier{
@@ -760,6 +890,7 @@ func TestBalance_Historical_Index(t *testing.T) {
 			&RosettaTypes.PartialBlockIdentifier{
 				Index: RosettaTypes.Int64(19388485),
 			},
+		nil,
 		)
 		assert.Equal(t, &RosettaTypes.AccountBalanceResponse{
 			BlockIdentifier: &RosettaTypes.BlockIdentifier{
@@ -821,6 +952,7 @@ func TestBalance_InvalidAddress(t *testing.T) {
 			Address: "0x4cfc400fed52f9681b42454c2db4b18ab98f8de",
 		},
 		nil,
+		nil,
 	)
 	assert.Nil(t, resp)
 	assert.Error(t, err)
@@ -871,6 +1003,7 @@ func TestBalance_InvalidHash(t *testing.T) {
 				"0x7d2a2713026a0e66f131878de2bb2df2fff6c24562c1df61ec0265e5fedf2626",
 			),
 		},
+		nil,
 	)
 	assert.Nil(t, resp)
 	assert.Error(t, err)
@@ -991,14 +1124,14 @@ func TestCall_GetTransactionReceipt(t *testing.T) {
 		nil,
 	).Run(
 		func(args mock.Arguments) {
-			r := args.Get(1).(**types.Receipt)
+			r := args.Get(1).(**Receipt)
 
 			file, err := ioutil.ReadFile(
 				"testdata/call_0xe8f6e3d60dd6ec3b28aa2f43a915d28924fc458c914d2ac0d52c7ec9e153268a.json",
 			)
 			assert.NoError(t, err)
 			t.Log("file :", string(file))
-			*r = new(types.Receipt)
+			*r = new(Receipt)
 
 			assert.NoError(t, (*r).UnmarshalJSON(file))
 			tmp, _ := json.Marshal(r)
@@ -1548,28 +1681,8 @@ func TestBlock_Genesis(t *testing.T) {
 			*r = json.RawMessage(file)
 		},
 	).Once()
-	mockJSONRPC.On(
-		"CallContext",
-		ctx,
-		mock.Anything,
-		"debug_traceBlockByHash",
-		common.HexToHash("0x10c1b0a564a4bffe38bd3cb93ed4df1a0055660093267d6aa6c8d836c992c295"),
-		tc,
-	).Return(
-		nil,
-	).Run(
-		func(args mock.Arguments) {
-			r := args.Get(1).(*json.RawMessage)
-
-			file, err := ioutil.ReadFile(
-				"testdata/block_trace_0x10c1b0a564a4bffe38bd3cb93ed4df1a0055660093267d6aa6c8d836c992c295.json",
-			) // nolint
-			assert.NoError(t, err)
-
-			*r = json.RawMessage(file)
-		},
-	).Once()
-
+	// Traces are not fetched at genesis, and its
+	// parent is reported as the genesis block.
 	correctRaw, err := ioutil.ReadFile("testdata/block_response_genesis.json")
 	assert.NoError(t, err)
 	var correct *RosettaTypes.BlockResponse
@@ -1660,30 +1773,25 @@ func TestBlock_14497230(t *testing.T) {
 		},
 	).Once()
 	mockJSONRPC.On(
-		"BatchCallContext",
+		"CallContext",
 		ctx,
 		mock.Anything,
+		"eth_getReceipts",
+		common.HexToHash("0x54849b67df3390cec858b4a77b1d4dc818ac6854a76950854cce8b871a1f117a"),
 	).Return(
 		nil,
 	).Run(
 		func(args mock.Arguments) {
-			r := args.Get(1).([]rpc.BatchElem)
-
-			assert.Len(t, r, 1)
-			assert.Equal(
-				t,
-				"0x47d4a3a76e13d96aa898e313ccb941966373dd9f9c668535e5a8f49c137af5b2",
-				r[0].Args[0],
-			)
+			r := args.Get(1).(*[]*Receipt)
 
 			file, err := ioutil.ReadFile(
 				"testdata/tx_receipt_0x47d4a3a76e13d96aa898e313ccb941966373dd9f9c668535e5a8f49c137af5b2.json",
 			) // nolint
 			assert.NoError(t, err)
 
-			receipt := new(types.Receipt)
+			receipt := new(Receipt)
 			assert.NoError(t, receipt.UnmarshalJSON(file))
-			*(r[0].Result.(**types.Receipt)) = receipt
+			*r = []*Receipt{receipt}
 		},
 	).Once()
 
@@ -2559,6 +2667,41 @@ func TestSuggestGasPrice(t *testing.T) {
 	mockGraphQL.AssertExpectations(t)
 }
 
+func TestSuggestGasTipCap(t *testing.T) {
+	mockJSONRPC := &mocks.JSONRPC{}
+	mockGraphQL := &mocks.GraphQL{}
+
+	c := &Client{
+		c:              mockJSONRPC,
+		g:              mockGraphQL,
+		traceSemaphore: semaphore.NewWeighted(100),
+	}
+
+	ctx := context.Background()
+	mockJSONRPC.On(
+		"CallContext",
+		ctx,
+		mock.Anything,
+		"eth_maxPriorityFeePerGas",
+	).Return(
+		nil,
+	).Run(
+		func(args mock.Arguments) {
+			r := args.Get(1).(*hexutil.Big)
+
+			*r = *(*hexutil.Big)(big.NewInt(1000000000))
+		},
+	).Once()
+	resp, err := c.SuggestGasTipCap(
+		ctx,
+	)
+	assert.Equal(t, big.NewInt(1000000000), resp)
+	assert.NoError(t, err)
+
+	mockJSONRPC.AssertExpectations(t)
+	mockGraphQL.AssertExpectations(t)
+}
+
 func TestSendTransaction(t *testing.T) {
 	mockJSONRPC := &mocks.JSONRPC{}
 	mockGraphQL := &mocks.GraphQL{}
@@ -2594,3 +2737,626 @@ func TestSendTransaction(t *testing.T) {
 	mockJSONRPC.AssertExpectations(t)
 	mockGraphQL.AssertExpectations(t)
 }
+
+func TestEstimateGas(t *testing.T) {
+	mockJSONRPC := &mocks.JSONRPC{}
+	mockGraphQL := &mocks.GraphQL{}
+
+	c := &Client{
+		c:              mockJSONRPC,
+		g:              mockGraphQL,
+		traceSemaphore: semaphore.NewWeighted(100),
+	}
+
+	ctx := context.Background()
+	to := common.HexToAddress("0x2d74530C0C196De44d3906822053bf336F18a16e")
+	data := ERC20TransferData(
+		common.HexToAddress("0x57B414a0332B5CaB885a451c2a28a07d1e9b8a8d"),
+		big.NewInt(1000),
+	)
+	mockJSONRPC.On(
+		"CallContext",
+		ctx,
+		mock.Anything,
+		"eth_estimateGas",
+		map[string]interface{}{
+			"from": common.HexToAddress("0xfFC614eE978630D7fB0C06758DeB580c152154d3"),
+			"to":   &to,
+			"data": hexutil.Bytes(data),
+		},
+	).Return(
+		nil,
+	).Run(
+		func(args mock.Arguments) {
+			r := args.Get(1).(*hexutil.Uint64)
+
+			*r = hexutil.Uint64(51000)
+		},
+	).Once()
+	resp, err := c.EstimateGas(
+		ctx,
+		ethereum.CallMsg{
+			From: common.HexToAddress("0xfFC614eE978630D7fB0C06758DeB580c152154d3"),
+			To:   &to,
+			Data: data,
+		},
+	)
+	assert.Equal(t, uint64(51000), resp)
+	assert.NoError(t, err)
+
+	mockJSONRPC.AssertExpectations(t)
+	mockGraphQL.AssertExpectations(t)
+}
+
+func TestCreateAccessList(t *testing.T) {
+	mockJSONRPC := &mocks.JSONRPC{}
+	mockGraphQL := &mocks.GraphQL{}
+
+	c := &Client{
+		c:              mockJSONRPC,
+		g:              mockGraphQL,
+		traceSemaphore: semaphore.NewWeighted(100),
+	}
+
+	ctx := context.Background()
+	to := common.HexToAddress("0x2d74530C0C196De44d3906822053bf336F18a16e")
+	data := ERC20TransferData(
+		common.HexToAddress("0x57B414a0332B5CaB885a451c2a28a07d1e9b8a8d"),
+		big.NewInt(1000),
+	)
+	accessList := types.AccessList{
+		{
+			Address: to,
+			StorageKeys: []common.Hash{
+				common.HexToHash("0x8d4c5d5c7e3f4b0f0d6b5f1f0e4e59f4d27d9c5cd8a5b0a1e8e5a3b1a0c0b0a1"),
+			},
+		},
+	}
+	msg := ethereum.CallMsg{
+		From: common.HexToAddress("0xfFC614eE978630D7fB0C06758DeB580c152154d3"),
+		To:   &to,
+		Data: data,
+	}
+	callArg := map[string]interface{}{
+		"from": common.HexToAddress("0xfFC614eE978630D7fB0C06758DeB580c152154d3"),
+		"to":   &to,
+		"data": hexutil.Bytes(data),
+	}
+	mockJSONRPC.On(
+		"CallContext",
+		ctx,
+		mock.Anything,
+		"eth_createAccessList",
+		callArg,
+		"pending",
+	).Return(
+		nil,
+	).Run(
+		func(args mock.Arguments) {
+			r := args.Get(1).(*accessListResult)
+
+			r.AccessList = &accessList
+		},
+	).Once()
+	resp, err := c.CreateAccessList(ctx, msg)
+	assert.Equal(t, accessList, resp)
+	assert.NoError(t, err)
+
+	// The access list of a failing call is not returned
+	mockJSONRPC.On(
+		"CallContext",
+		ctx,
+		mock.Anything,
+		"eth_createAccessList",
+		callArg,
+		"pending",
+	).Return(
+		nil,
+	).Run(
+		func(args mock.Arguments) {
+			r := args.Get(1).(*accessListResult)
+
+			r.AccessList = &accessList
+			r.Error = "execution reverted"
+		},
+	).Once()
+	resp, err = c.CreateAccessList(ctx, msg)
+	assert.Nil(t, resp)
+	assert.EqualError(t, err, "unable to create access list: execution reverted")
+
+	mockJSONRPC.AssertExpectations(t)
+	mockGraphQL.AssertExpectations(t)
+}
+
+func TestTransaction(t *testing.T) {
+	mockJSONRPC := &mocks.JSONRPC{}
+	mockGraphQL := &mocks.GraphQL{}
+
+	tc, err := testTraceConfig()
+	assert.NoError(t, err)
+	c := &Client{
+		c:              mockJSONRPC,
+		g:              mockGraphQL,
+		tc:             tc,
+		p:              params.MetadiumTestnetChainConfig,
+		traceSemaphore: semaphore.NewWeighted(100),
+	}
+
+	ctx := context.Background()
+	blockHash := "0x54849b67df3390cec858b4a77b1d4dc818ac6854a76950854cce8b871a1f117a"
+	txHash := "0x47d4a3a76e13d96aa898e313ccb941966373dd9f9c668535e5a8f49c137af5b2"
+	mockJSONRPC.On(
+		"CallContext",
+		ctx,
+		mock.Anything,
+		"eth_getBlockByHash",
+		blockHash,
+		false,
+	).Return(
+		nil,
+	).Run(
+		func(args mock.Arguments) {
+			r := args.Get(1).(**types.Header)
+
+			file, err := ioutil.ReadFile("testdata/block_14497230.json")
+			assert.NoError(t, err)
+
+			assert.NoError(t, json.Unmarshal(file, r))
+		},
+	).Once()
+	mockJSONRPC.On(
+		"CallContext",
+		ctx,
+		mock.Anything,
+		"eth_getTransactionByHash",
+		common.HexToHash(txHash),
+	).Return(
+		nil,
+	).Run(
+		func(args mock.Arguments) {
+			r := args.Get(1).(**rpcTransaction)
+
+			file, err := ioutil.ReadFile("testdata/tx_" + txHash + ".json")
+			assert.NoError(t, err)
+
+			assert.NoError(t, json.Unmarshal(file, r))
+		},
+	).Once()
+	mockJSONRPC.On(
+		"CallContext",
+		ctx,
+		mock.Anything,
+		"eth_getTransactionReceipt",
+		common.HexToHash(txHash),
+	).Return(
+		nil,
+	).Run(
+		func(args mock.Arguments) {
+			r := args.Get(1).(**Receipt)
+
+			file, err := ioutil.ReadFile("testdata/tx_receipt_" + txHash + ".json")
+			assert.NoError(t, err)
+
+			assert.NoError(t, json.Unmarshal(file, r))
+		},
+	).Once()
+	mockJSONRPC.On(
+		"CallContext",
+		ctx,
+		mock.Anything,
+		"debug_traceTransaction",
+		common.HexToHash(txHash),
+		tc,
+	).Return(
+		nil,
+	).Run(
+		func(args mock.Arguments) {
+			r := args.Get(1).(*json.RawMessage)
+
+			file, err := ioutil.ReadFile("testdata/tx_trace_" + txHash + ".json")
+			assert.NoError(t, err)
+
+			*r = json.RawMessage(file)
+		},
+	).Once()
+
+	correctRaw, err := ioutil.ReadFile("testdata/block_transaction_response_" + txHash + ".json")
+	assert.NoError(t, err)
+	var correctResp *RosettaTypes.BlockTransactionResponse
+	assert.NoError(t, json.Unmarshal(correctRaw, &correctResp))
+
+	resp, err := c.Transaction(
+		ctx,
+		&RosettaTypes.BlockIdentifier{
+			Index: 14497230,
+			Hash:  blockHash,
+		},
+		&RosettaTypes.TransactionIdentifier{
+			Hash: txHash,
+		},
+	)
+	assert.NoError(t, err)
+
+	// Ensure types match
+	jsonResp, err := json.Marshal(resp)
+	assert.NoError(t, err)
+	var tx *RosettaTypes.Transaction
+	assert.NoError(t, json.Unmarshal(jsonResp, &tx))
+	assert.Equal(t, correctResp.Transaction, tx)
+
+	mockJSONRPC.AssertExpectations(t)
+	mockGraphQL.AssertExpectations(t)
+}
+
+func TestTransaction_NotInBlock(t *testing.T) {
+	mockJSONRPC := &mocks.JSONRPC{}
+	mockGraphQL := &mocks.GraphQL{}
+
+	c := &Client{
+		c:              mockJSONRPC,
+		g:              mockGraphQL,
+		traceSemaphore: semaphore.NewWeighted(100),
+	}
+
+	ctx := context.Background()
+	blockHash := "0x54849b67df3390cec858b4a77b1d4dc818ac6854a76950854cce8b871a1f117a"
+	txHash := "0x47d4a3a76e13d96aa898e313ccb941966373dd9f9c668535e5a8f49c137af5b2"
+	mockJSONRPC.On(
+		"CallContext",
+		ctx,
+		mock.Anything,
+		"eth_getBlockByHash",
+		blockHash,
+		false,
+	).Return(
+		nil,
+	).Run(
+		func(args mock.Arguments) {
+			r := args.Get(1).(**types.Header)
+
+			file, err := ioutil.ReadFile("testdata/block_14497230.json")
+			assert.NoError(t, err)
+
+			assert.NoError(t, json.Unmarshal(file, r))
+		},
+	).Once()
+	mockJSONRPC.On(
+		"CallContext",
+		ctx,
+		mock.Anything,
+		"eth_getTransactionByHash",
+		common.HexToHash(txHash),
+	).Return(
+		nil,
+	).Once()
+
+	resp, err := c.Transaction(
+		ctx,
+		&RosettaTypes.BlockIdentifier{
+			Index: 14497230,
+			Hash:  blockHash,
+		},
+		&RosettaTypes.TransactionIdentifier{
+			Hash: txHash,
+		},
+	)
+	assert.Nil(t, resp)
+	assert.True(t, errors.Is(err, ErrTransactionNotFound))
+
+	mockJSONRPC.AssertExpectations(t)
+	mockGraphQL.AssertExpectations(t)
+}
+
+func TestMempool(t *testing.T) {
+	mockJSONRPC := &mocks.JSONRPC{}
+	mockGraphQL := &mocks.GraphQL{}
+
+	c := &Client{
+		c:              mockJSONRPC,
+		g:              mockGraphQL,
+		traceSemaphore: semaphore.NewWeighted(100),
+	}
+
+	ctx := context.Background()
+	mockJSONRPC.On(
+		"CallContext",
+		ctx,
+		mock.Anything,
+		"txpool_content",
+	).Return(
+		nil,
+	).Run(
+		func(args mock.Arguments) {
+			r := args.Get(1).(*txPoolContentResponse)
+
+			file, err := ioutil.ReadFile("testdata/txpool_content.json")
+			assert.NoError(t, err)
+
+			assert.NoError(t, json.Unmarshal(file, r))
+		},
+	).Once()
+
+	resp, err := c.Mempool(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []*RosettaTypes.TransactionIdentifier{
+		{
+			Hash: "0x0dd0ba7c1f4fb8fb0d5b4f2b8ffa7bcbdf5ab5ba1aa3c1b3e4f6cd9f1e2b3a4c",
+		},
+		{
+			Hash: "0x4566dbec2871bb31fa50adf6d819014a8a0c90465817de304a35cd9362481ac6",
+		},
+	}, resp)
+
+	mockJSONRPC.AssertExpectations(t)
+	mockGraphQL.AssertExpectations(t)
+}
+
+func TestPendingTransaction(t *testing.T) {
+	mockJSONRPC := &mocks.JSONRPC{}
+	mockGraphQL := &mocks.GraphQL{}
+
+	c := &Client{
+		c:              mockJSONRPC,
+		g:              mockGraphQL,
+		traceSemaphore: semaphore.NewWeighted(100),
+	}
+
+	ctx := context.Background()
+	mockJSONRPC.On(
+		"CallContext",
+		ctx,
+		mock.Anything,
+		"txpool_content",
+	).Return(
+		nil,
+	).Run(
+		func(args mock.Arguments) {
+			r := args.Get(1).(*txPoolTransactionsResponse)
+
+			file, err := ioutil.ReadFile("testdata/txpool_content.json")
+			assert.NoError(t, err)
+
+			assert.NoError(t, json.Unmarshal(file, r))
+		},
+	).Twice()
+
+	from := common.HexToAddress("0x71562b71999873DB5b286dF957af199Ec94617F7")
+	tx, hash, err := c.PendingTransaction(ctx, from, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, "0x4566dbec2871bb31fa50adf6d819014a8a0c90465817de304a35cd9362481ac6", tx.Hash().Hex())
+	assert.Equal(t, tx.Hash(), hash)
+	assert.Equal(t, big.NewInt(80000000000), tx.GasPrice())
+
+	tx, _, err = c.PendingTransaction(ctx, from, 4)
+	assert.True(t, errors.Is(err, ErrTransactionNotFound))
+	assert.Nil(t, tx)
+
+	mockJSONRPC.AssertExpectations(t)
+}
+
+func TestFeeHistory(t *testing.T) {
+	mockJSONRPC := &mocks.JSONRPC{}
+	mockGraphQL := &mocks.GraphQL{}
+
+	c := &Client{
+		c:              mockJSONRPC,
+		g:              mockGraphQL,
+		traceSemaphore: semaphore.NewWeighted(100),
+	}
+
+	ctx := context.Background()
+	percentiles := []float64{10, 50, 90}
+	mockJSONRPC.On(
+		"CallContext",
+		ctx,
+		mock.Anything,
+		"eth_feeHistory",
+		hexutil.Uint64(4),
+		"latest",
+		percentiles,
+	).Return(
+		nil,
+	).Run(
+		func(args mock.Arguments) {
+			r := args.Get(1).(*feeHistoryResult)
+
+			file, err := ioutil.ReadFile("testdata/fee_history.json")
+			assert.NoError(t, err)
+
+			assert.NoError(t, json.Unmarshal(file, r))
+		},
+	).Once()
+
+	history, err := c.FeeHistory(ctx, 4, percentiles)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(14498514), history.OldestBlock)
+	assert.Len(t, history.Reward, 4)
+	assert.Equal(t, big.NewInt(80000000000), history.Reward[0][0])
+	assert.Equal(t, big.NewInt(100000000000), history.Reward[0][2])
+	assert.Zero(t, history.Reward[1][1].Sign())
+	assert.Len(t, history.BaseFee, 5)
+	assert.Equal(t, []float64{0.0042, 0, 0.015, 0.001}, history.GasUsedRatio)
+
+	mockJSONRPC.AssertExpectations(t)
+}
+
+func TestMempoolTransaction(t *testing.T) {
+	mockJSONRPC := &mocks.JSONRPC{}
+	mockGraphQL := &mocks.GraphQL{}
+
+	c := &Client{
+		c:              mockJSONRPC,
+		g:              mockGraphQL,
+		traceSemaphore: semaphore.NewWeighted(100),
+	}
+
+	ctx := context.Background()
+	txHash := "0x4566dbec2871bb31fa50adf6d819014a8a0c90465817de304a35cd9362481ac6"
+	mockJSONRPC.On(
+		"CallContext",
+		ctx,
+		mock.Anything,
+		"eth_getTransactionByHash",
+		common.HexToHash(txHash),
+	).Return(
+		nil,
+	).Run(
+		func(args mock.Arguments) {
+			r := args.Get(1).(**rpcTransaction)
+
+			file, err := ioutil.ReadFile("testdata/tx_" + txHash + ".json")
+			assert.NoError(t, err)
+
+			assert.NoError(t, json.Unmarshal(file, r))
+		},
+	).Once()
+
+	correctRaw, err := ioutil.ReadFile("testdata/mempool_transaction_response_" + txHash + ".json")
+	assert.NoError(t, err)
+	var correctResp *RosettaTypes.MempoolTransactionResponse
+	assert.NoError(t, json.Unmarshal(correctRaw, &correctResp))
+
+	resp, err := c.MempoolTransaction(
+		ctx,
+		&RosettaTypes.TransactionIdentifier{
+			Hash: txHash,
+		},
+	)
+	assert.NoError(t, err)
+
+	// Ensure types match
+	jsonResp, err := json.Marshal(resp)
+	assert.NoError(t, err)
+	var tx *RosettaTypes.Transaction
+	assert.NoError(t, json.Unmarshal(jsonResp, &tx))
+	assert.Equal(t, correctResp.Transaction, tx)
+
+	mockJSONRPC.AssertExpectations(t)
+	mockGraphQL.AssertExpectations(t)
+}
+
+func TestMempoolTransaction_Mined(t *testing.T) {
+	mockJSONRPC := &mocks.JSONRPC{}
+	mockGraphQL := &mocks.GraphQL{}
+
+	c := &Client{
+		c:              mockJSONRPC,
+		g:              mockGraphQL,
+		traceSemaphore: semaphore.NewWeighted(100),
+	}
+
+	ctx := context.Background()
+	txHash := "0x47d4a3a76e13d96aa898e313ccb941966373dd9f9c668535e5a8f49c137af5b2"
+	mockJSONRPC.On(
+		"CallContext",
+		ctx,
+		mock.Anything,
+		"eth_getTransactionByHash",
+		common.HexToHash(txHash),
+	).Return(
+		nil,
+	).Run(
+		func(args mock.Arguments) {
+			r := args.Get(1).(**rpcTransaction)
+
+			file, err := ioutil.ReadFile("testdata/tx_" + txHash + ".json")
+			assert.NoError(t, err)
+
+			assert.NoError(t, json.Unmarshal(file, r))
+		},
+	).Once()
+
+	resp, err := c.MempoolTransaction(
+		ctx,
+		&RosettaTypes.TransactionIdentifier{
+			Hash: txHash,
+		},
+	)
+	assert.Nil(t, resp)
+	assert.True(t, errors.Is(err, ErrTransactionNotFound))
+
+	mockJSONRPC.AssertExpectations(t)
+	mockGraphQL.AssertExpectations(t)
+}
+
+func TestDryRun(t *testing.T) {
+	mockJSONRPC := &mocks.JSONRPC{}
+	mockGraphQL := &mocks.GraphQL{}
+
+	tc, err := testTraceConfig()
+	assert.NoError(t, err)
+	c := &Client{
+		c:              mockJSONRPC,
+		g:              mockGraphQL,
+		tc:             tc,
+		traceSemaphore: semaphore.NewWeighted(100),
+	}
+
+	ctx := context.Background()
+	from := common.HexToAddress("0x2974f845435eaf97dcb1ba4a6a6f8cf2b9afb882")
+	to := common.HexToAddress("0x4b8d211c9c997079c3cf47c5010071b328af9515")
+	msg := ethereum.CallMsg{
+		From:      from,
+		To:        &to,
+		Gas:       50000,
+		GasFeeCap: big.NewInt(161000000000),
+		GasTipCap: big.NewInt(1000000000),
+		Value:     big.NewInt(1000),
+		Data:      common.FromHex("0xd0e30db0"),
+	}
+	mockTrace := func(fixture string) {
+		mockJSONRPC.On(
+			"CallContext",
+			ctx,
+			mock.Anything,
+			"debug_traceCall",
+			map[string]interface{}{
+				"from":                 from,
+				"to":                   &to,
+				"data":                 hexutil.Bytes(msg.Data),
+				"value":                (*hexutil.Big)(msg.Value),
+				"gas":                  hexutil.Uint64(msg.Gas),
+				"maxFeePerGas":         (*hexutil.Big)(msg.GasFeeCap),
+				"maxPriorityFeePerGas": (*hexutil.Big)(msg.GasTipCap),
+			},
+			"latest",
+			tc,
+		).Return(
+			nil,
+		).Run(
+			func(args mock.Arguments) {
+				r := args.Get(1).(*json.RawMessage)
+
+				file, err := ioutil.ReadFile("testdata/" + fixture)
+				assert.NoError(t, err)
+
+				*r = json.RawMessage(file)
+			},
+		).Once()
+	}
+
+	mockTrace("dry_run_transfer.json")
+	dryRun, err := c.DryRun(ctx, msg)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(31260), dryRun.GasUsed)
+	assert.False(t, dryRun.Reverted)
+	assert.Empty(t, dryRun.RevertReason)
+	assert.Len(t, dryRun.Operations, 4)
+	assert.Equal(t, MustChecksum(from.Hex()), dryRun.Operations[0].Account.Address)
+	assert.Equal(t, "-1000", dryRun.Operations[0].Amount.Value)
+	assert.Equal(t, MustChecksum(to.Hex()), dryRun.Operations[1].Account.Address)
+	assert.Equal(t, "1000", dryRun.Operations[1].Amount.Value)
+	assert.Equal(t, "-100", dryRun.Operations[2].Amount.Value)
+	assert.Equal(t, "0x72FdE95fF344A6E0b681Db80BD6D917A5610d11e", dryRun.Operations[3].Account.Address)
+	assert.Equal(t, SuccessStatus, *dryRun.Operations[3].Status)
+
+	mockTrace("dry_run_revert.json")
+	dryRun, err = c.DryRun(ctx, msg)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(23693), dryRun.GasUsed)
+	assert.True(t, dryRun.Reverted)
+	assert.Equal(t, "insufficient allowance", dryRun.RevertReason)
+	assert.Empty(t, dryRun.Operations)
+
+	mockJSONRPC.AssertExpectations(t)
+}
@@ -0,0 +1,307 @@
+// Copyright 2020 Coinbase, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadium
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// MethodSignatureKey is the metadata key holding the
+	// signature of a contract method, e.g. "vote(uint256,bool)".
+	MethodSignatureKey = "method_signature"
+
+	// MethodArgsKey is the metadata key holding the
+	// arguments of a contract method as strings.
+	MethodArgsKey = "method_args"
+
+	// CallDataKey is the metadata key holding raw
+	// hex encoded calldata.
+	CallDataKey = "data"
+
+	// BytecodeKey is the metadata key holding the hex
+	// encoded bytecode of a contract to deploy.
+	BytecodeKey = "bytecode"
+
+	methodIDLength = 4
+)
+
+var (
+	// ErrInvalidMethodSignature is returned when a method
+	// signature cannot be parsed.
+	ErrInvalidMethodSignature = errors.New("invalid method signature")
+
+	// ErrInvalidMethodArgs is returned when the arguments of
+	// a contract call do not match its method signature.
+	ErrInvalidMethodArgs = errors.New("invalid method arguments")
+)
+
+// MethodID returns the 4-byte selector of a method signature.
+func MethodID(signature string) []byte {
+	return crypto.Keccak256([]byte(signature))[:methodIDLength]
+}
+
+// parseMethodSignature returns the canonical form of signature
+// and the ABI arguments of the method. Tuple arguments are not
+// supported.
+func parseMethodSignature(signature string) (string, abi.Arguments, error) {
+	signature = strings.ReplaceAll(signature, " ", "")
+	open := strings.Index(signature, "(")
+	if open <= 0 || !strings.HasSuffix(signature, ")") {
+		return "", nil, fmt.Errorf("%w: %s", ErrInvalidMethodSignature, signature)
+	}
+
+	params := signature[open+1 : len(signature)-1]
+	if len(params) == 0 {
+		return signature, abi.Arguments{}, nil
+	}
+
+	var args abi.Arguments
+	for _, param := range strings.Split(params, ",") {
+		typ, err := abi.NewType(param, "", nil)
+		if err != nil {
+			return "", nil, fmt.Errorf("%w: %s: %s", ErrInvalidMethodSignature, signature, err.Error())
+		}
+		if typ.T == abi.TupleTy {
+			return "", nil, fmt.Errorf("%w: %s: tuples are not supported", ErrInvalidMethodSignature, signature)
+		}
+
+		args = append(args, abi.Argument{Type: typ})
+	}
+
+	return signature, args, nil
+}
+
+// ContractCallData returns the calldata of a call to the
+// method with the provided signature. Each argument is
+// provided as a string: addresses, bytes and fixed bytes in
+// hex, integers in decimal and booleans as "true" or "false".
+func ContractCallData(signature string, args []string) ([]byte, error) {
+	signature, packed, err := packArgs(signature, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(MethodID(signature), packed...), nil
+}
+
+// ContractCreationData returns the init code deploying bytecode with
+// the provided constructor arguments, formatted as in ContractCallData.
+// The constructor signature, e.g. "constructor(uint256,address)", is
+// only required when arguments are provided.
+func ContractCreationData(
+	bytecode []byte,
+	constructorSignature string,
+	args []string,
+) ([]byte, error) {
+	if len(bytecode) == 0 {
+		return nil, fmt.Errorf("%w: %s is empty", ErrInvalidMethodArgs, BytecodeKey)
+	}
+
+	data := make([]byte, len(bytecode))
+	copy(data, bytecode)
+	if len(constructorSignature) == 0 {
+		if len(args) > 0 {
+			return nil, fmt.Errorf("%w: constructor arguments require a signature", ErrInvalidMethodArgs)
+		}
+
+		return data, nil
+	}
+
+	_, packed, err := packArgs(constructorSignature, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(data, packed...), nil
+}
+
+// packArgs ABI encodes args as the arguments of the method with
+// the provided signature. It returns the canonical signature.
+func packArgs(signature string, args []string) (string, []byte, error) {
+	signature, abiArgs, err := parseMethodSignature(signature)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if len(args) != len(abiArgs) {
+		return "", nil, fmt.Errorf(
+			"%w: %s expects %d arguments, got %d",
+			ErrInvalidMethodArgs,
+			signature,
+			len(abiArgs),
+			len(args),
+		)
+	}
+
+	values := make([]interface{}, len(args))
+	for i, arg := range args {
+		value, err := parseABIValue(abiArgs[i].Type, arg)
+		if errors.Is(err, ErrCallParametersInvalid) {
+			return "", nil, fmt.Errorf("argument %d: %w", i, err)
+		}
+		if err != nil {
+			return "", nil, fmt.Errorf("%w: argument %d: %s", ErrInvalidMethodArgs, i, err.Error())
+		}
+		values[i] = value
+	}
+
+	packed, err := abiArgs.Pack(values...)
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: %s", ErrInvalidMethodArgs, err.Error())
+	}
+
+	return signature, packed, nil
+}
+
+// ParseContractCallData decodes the arguments of calldata made
+// to the method with the provided signature. The arguments are
+// returned in the format accepted by ContractCallData.
+func ParseContractCallData(signature string, data []byte) ([]string, error) {
+	signature, abiArgs, err := parseMethodSignature(signature)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) < methodIDLength || !bytes.Equal(data[:methodIDLength], MethodID(signature)) {
+		return nil, fmt.Errorf("%w: calldata is not a call to %s", ErrInvalidMethodArgs, signature)
+	}
+
+	values, err := abiArgs.UnpackValues(data[methodIDLength:])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidMethodArgs, err.Error())
+	}
+
+	args := make([]string, len(values))
+	for i, value := range values {
+		args[i] = formatABIValue(value)
+	}
+
+	return args, nil
+}
+
+// parseABIValue converts arg into the Go value expected
+// by the ABI encoder for typ.
+func parseABIValue(typ abi.Type, arg string) (interface{}, error) {
+	switch typ.T {
+	case abi.AddressTy:
+		if !common.IsHexAddress(arg) {
+			return nil, fmt.Errorf("%s is not a valid address", arg)
+		}
+		return common.HexToAddress(arg), nil
+	case abi.BoolTy:
+		return strconv.ParseBool(arg)
+	case abi.StringTy:
+		return arg, nil
+	case abi.BytesTy:
+		return hexutil.Decode(arg)
+	case abi.FixedBytesTy:
+		b, err := hexutil.Decode(arg)
+		if err != nil {
+			return nil, err
+		}
+		if len(b) != typ.Size {
+			return nil, fmt.Errorf("expected %d bytes, got %d", typ.Size, len(b))
+		}
+
+		value := reflect.New(typ.GetType()).Elem()
+		reflect.Copy(value, reflect.ValueOf(b))
+		return value.Interface(), nil
+	case abi.IntTy, abi.UintTy:
+		i, ok := new(big.Int).SetString(arg, 10) // nolint:gomnd
+		if !ok {
+			return nil, fmt.Errorf("%s is not a valid integer", arg)
+		}
+
+		if typ.T == abi.UintTy && i.Sign() < 0 {
+			return nil, fmt.Errorf("%w: %s is negative", ErrCallParametersInvalid, arg)
+		}
+
+		// The ABI encoder wraps integers that do not fit in typ,
+		// which would silently change the encoded value.
+		min, max := integerRange(typ)
+		if i.Cmp(min) < 0 || i.Cmp(max) > 0 {
+			return nil, fmt.Errorf("%w: %s overflows %s", ErrCallParametersInvalid, arg, typ.String())
+		}
+
+		// Integers of up to 64 bits are packed from
+		// the matching Go type.
+		goType := typ.GetType()
+		if goType.Kind() == reflect.Ptr {
+			return i, nil
+		}
+		if typ.T == abi.UintTy {
+			return reflect.ValueOf(i.Uint64()).Convert(goType).Interface(), nil
+		}
+		return reflect.ValueOf(i.Int64()).Convert(goType).Interface(), nil
+	default:
+		return nil, fmt.Errorf("%s is not supported", typ.String())
+	}
+}
+
+// integerRange returns the smallest and largest
+// values of the integer type typ.
+func integerRange(typ abi.Type) (*big.Int, *big.Int) {
+	if typ.T == abi.UintTy {
+		max := new(big.Int).Lsh(big.NewInt(1), uint(typ.Size))
+		return big.NewInt(0), max.Sub(max, big.NewInt(1))
+	}
+
+	max := new(big.Int).Lsh(big.NewInt(1), uint(typ.Size-1))
+	min := new(big.Int).Neg(max)
+	return min, max.Sub(max, big.NewInt(1))
+}
+
+// formatABIValue converts a value decoded by the ABI
+// decoder into the string accepted by parseABIValue.
+func formatABIValue(value interface{}) string {
+	switch v := value.(type) {
+	case common.Address:
+		return v.Hex()
+	case *big.Int:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	case string:
+		return v
+	case []byte:
+		return hexutil.Encode(v)
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() { // nolint:exhaustive
+	case reflect.Array:
+		b := make([]byte, rv.Len())
+		reflect.Copy(reflect.ValueOf(b), rv)
+		return hexutil.Encode(b)
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10) // nolint:gomnd
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10) // nolint:gomnd
+	default:
+		return fmt.Sprint(value)
+	}
+}
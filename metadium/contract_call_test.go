@@ -0,0 +1,174 @@
+// Copyright 2020 Coinbase, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadium
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContractCallData(t *testing.T) {
+	to := common.HexToAddress("0x57B414a0332B5CaB885a451c2a28a07d1e9b8a8d")
+
+	// transfer(address,uint256) must match the ERC-20 encoder.
+	data, err := ContractCallData(
+		"transfer(address, uint256)",
+		[]string{to.Hex(), "1000"},
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, ERC20TransferData(to, big.NewInt(1000)), data)
+
+	args, err := ParseContractCallData("transfer(address,uint256)", data)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{to.Hex(), "1000"}, args)
+
+	tests := map[string]struct {
+		signature string
+		args      []string
+		err       error
+	}{
+		"no arguments": {
+			signature: "execute()",
+			args:      []string{},
+		},
+		"mixed types": {
+			signature: "vote(uint8,int64,bool,bytes32,bytes,string)",
+			args: []string{
+				"2",
+				"-5",
+				"true",
+				"0x0000000000000000000000000000000000000000000000000000000000000001",
+				"0xdeadbeef",
+				"yes",
+			},
+		},
+		"invalid signature": {
+			signature: "vote",
+			args:      []string{},
+			err:       ErrInvalidMethodSignature,
+		},
+		"unknown type": {
+			signature: "vote(foo)",
+			args:      []string{"1"},
+			err:       ErrInvalidMethodSignature,
+		},
+		"wrong argument count": {
+			signature: "vote(uint256)",
+			args:      []string{},
+			err:       ErrInvalidMethodArgs,
+		},
+		"large integers": {
+			signature: "vote(uint256,uint128,int256,int128)",
+			args: []string{
+				"115792089237316195423570985008687907853269984665640564039457584007913129639935",
+				"340282366920938463463374607431768211455",
+				"-57896044618658097711785492504343953926634992332820282019728792003956564819968",
+				"170141183460469231731687303715884105727",
+			},
+		},
+		"overflow": {
+			signature: "vote(uint8)",
+			args:      []string{"256"},
+			err:       ErrCallParametersInvalid,
+		},
+		"uint256 overflow": {
+			signature: "approve(address,uint256)",
+			args: []string{
+				"0x57B414a0332B5CaB885a451c2a28a07d1e9b8a8d",
+				"115792089237316195423570985008687907853269984665640564039457584007913129639941",
+			},
+			err: ErrCallParametersInvalid,
+		},
+		"uint128 overflow": {
+			signature: "vote(uint128)",
+			args:      []string{"340282366920938463463374607431768211456"},
+			err:       ErrCallParametersInvalid,
+		},
+		"int256 overflow": {
+			signature: "vote(int256)",
+			args:      []string{"57896044618658097711785492504343953926634992332820282019728792003956564819968"},
+			err:       ErrCallParametersInvalid,
+		},
+		"int128 underflow": {
+			signature: "vote(int128)",
+			args:      []string{"-170141183460469231731687303715884105729"},
+			err:       ErrCallParametersInvalid,
+		},
+		"negative uint": {
+			signature: "vote(uint256)",
+			args:      []string{"-1"},
+			err:       ErrCallParametersInvalid,
+		},
+		"negative uint128": {
+			signature: "vote(uint128)",
+			args:      []string{"-5"},
+			err:       ErrCallParametersInvalid,
+		},
+		"invalid address": {
+			signature: "vote(address)",
+			args:      []string{"0x123"},
+			err:       ErrInvalidMethodArgs,
+		},
+		"unsupported array": {
+			signature: "vote(uint256[])",
+			args:      []string{"1"},
+			err:       ErrInvalidMethodArgs,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			data, err := ContractCallData(test.signature, test.args)
+			if test.err != nil {
+				assert.True(t, errors.Is(err, test.err))
+				return
+			}
+
+			assert.NoError(t, err)
+			args, err := ParseContractCallData(test.signature, data)
+			assert.NoError(t, err)
+			assert.Equal(t, test.args, args)
+		})
+	}
+
+	_, err = ParseContractCallData("vote(uint256)", data)
+	assert.True(t, errors.Is(err, ErrInvalidMethodArgs))
+}
+
+func TestContractCreationData(t *testing.T) {
+	bytecode := []byte{0x60, 0x80, 0x60, 0x40}
+
+	data, err := ContractCreationData(bytecode, "", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, bytecode, data)
+
+	data, err = ContractCreationData(bytecode, "constructor(uint256)", []string{"1000"})
+	assert.NoError(t, err)
+	assert.Equal(t, append(bytecode, common.LeftPadBytes(big.NewInt(1000).Bytes(), 32)...), data)
+	assert.Equal(t, []byte{0x60, 0x80, 0x60, 0x40}, bytecode)
+
+	_, err = ContractCreationData(nil, "", nil)
+	assert.True(t, errors.Is(err, ErrInvalidMethodArgs))
+
+	_, err = ContractCreationData(bytecode, "", []string{"1000"})
+	assert.True(t, errors.Is(err, ErrInvalidMethodArgs))
+
+	_, err = ContractCreationData(bytecode, "constructor(uint256)", []string{})
+	assert.True(t, errors.Is(err, ErrInvalidMethodArgs))
+}
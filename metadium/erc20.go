@@ -0,0 +1,403 @@
+// Copyright 2020 Coinbase, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadium
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	EthTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// ContractAddressKey is the key in *types.Currency.Metadata
+	// that holds the address of an ERC-20 token contract.
+	ContractAddressKey = "contractAddress"
+
+	// TransferFnSignature is the signature of the ERC-20
+	// transfer method.
+	TransferFnSignature = "transfer(address,uint256)"
+
+	// TransferEventSignature is the signature of the ERC-20
+	// Transfer event.
+	TransferEventSignature = "Transfer(address,address,uint256)"
+
+	symbolFnSignature    = "symbol()"
+	decimalsFnSignature  = "decimals()"
+	balanceOfFnSignature = "balanceOf(address)"
+
+	// transferEventTopics is the number of topics of an ERC-20
+	// Transfer event (ERC-721 also indexes the token id).
+	transferEventTopics = 3
+
+	// erc20ArgLength is the length of an ABI encoded
+	// static argument.
+	erc20ArgLength = 32
+)
+
+var (
+	// TransferMethodID is the 4-byte selector of TransferFnSignature.
+	TransferMethodID = crypto.Keccak256([]byte(TransferFnSignature))[:4]
+
+	// TransferEventTopic is the topic of TransferEventSignature.
+	TransferEventTopic = crypto.Keccak256Hash([]byte(TransferEventSignature))
+
+	symbolMethodID    = crypto.Keccak256([]byte(symbolFnSignature))[:4]
+	decimalsMethodID  = crypto.Keccak256([]byte(decimalsFnSignature))[:4]
+	balanceOfMethodID = crypto.Keccak256([]byte(balanceOfFnSignature))[:4]
+
+	// executionErrors are the errors returned by gmet for calls that
+	// fail in the EVM, as calls to methods a contract does not have.
+	// Other errors, like missing state, are not about the contract.
+	executionErrors = []string{
+		"execution reverted",
+		"invalid opcode",
+		"invalid jump destination",
+	}
+)
+
+// IsNativeCurrency returns a boolean indicating if
+// the provided currency is META.
+func IsNativeCurrency(currency *types.Currency) bool {
+	if currency == nil {
+		return false
+	}
+
+	return types.Hash(currency) == types.Hash(Currency)
+}
+
+// TokenContractAddress returns the checksummed contract
+// address stored in the metadata of an ERC-20 currency. If
+// the currency does not carry a valid contract address, it
+// returns !ok.
+func TokenContractAddress(currency *types.Currency) (string, bool) {
+	if currency == nil || currency.Metadata == nil {
+		return "", false
+	}
+
+	address, ok := currency.Metadata[ContractAddressKey].(string)
+	if !ok {
+		return "", false
+	}
+
+	return ChecksumAddress(address)
+}
+
+// ERC20TransferData returns the calldata of a
+// transfer(address,uint256) call.
+func ERC20TransferData(to common.Address, amount *big.Int) []byte {
+	data := make([]byte, 0, len(TransferMethodID)+2*erc20ArgLength)
+	data = append(data, TransferMethodID...)
+	data = append(data, common.LeftPadBytes(to.Bytes(), erc20ArgLength)...)
+	data = append(data, common.LeftPadBytes(amount.Bytes(), erc20ArgLength)...)
+
+	return data
+}
+
+// ParseERC20TransferData decodes the recipient and amount of
+// transfer(address,uint256) calldata. If the data is not a
+// transfer call, it returns !ok.
+func ParseERC20TransferData(data []byte) (common.Address, *big.Int, bool) {
+	if len(data) != len(TransferMethodID)+2*erc20ArgLength {
+		return common.Address{}, nil, false
+	}
+
+	if !bytes.Equal(data[:len(TransferMethodID)], TransferMethodID) {
+		return common.Address{}, nil, false
+	}
+
+	args := data[len(TransferMethodID):]
+	to := common.BytesToAddress(args[:erc20ArgLength])
+	amount := new(big.Int).SetBytes(args[erc20ArgLength:])
+
+	return to, amount, true
+}
+
+// parseERC20TransferLog decodes the sender, recipient and amount
+// of an ERC-20 Transfer event. If the log is not such an event,
+// it returns !ok.
+func parseERC20TransferLog(l *EthTypes.Log) (common.Address, common.Address, *big.Int, bool) {
+	if len(l.Topics) != transferEventTopics || l.Topics[0] != TransferEventTopic {
+		return common.Address{}, common.Address{}, nil, false
+	}
+
+	if len(l.Data) != erc20ArgLength {
+		return common.Address{}, common.Address{}, nil, false
+	}
+
+	from := common.BytesToAddress(l.Topics[1].Bytes())
+	to := common.BytesToAddress(l.Topics[2].Bytes())
+	amount := new(big.Int).SetBytes(l.Data)
+
+	return from, to, amount, true
+}
+
+// erc20Ops returns all *types.Operation for the ERC-20 Transfer
+// events emitted by a successful transaction. Transfers from or to the
+// zero address (mints and burns) only produce an operation for the
+// other account.
+func erc20Ops(
+	receipt *Receipt,
+	currencies map[common.Address]*types.Currency,
+	startIndex int,
+) []*types.Operation {
+	var ops []*types.Operation
+	if receipt == nil || receipt.Status != EthTypes.ReceiptStatusSuccessful {
+		return ops
+	}
+
+	for _, l := range receipt.Logs {
+		from, to, amount, ok := parseERC20TransferLog(l)
+		if !ok || amount.Sign() == 0 {
+			continue
+		}
+
+		currency, ok := currencies[l.Address]
+		if !ok {
+			continue
+		}
+
+		var fromIndex *types.OperationIdentifier
+		if from != (common.Address{}) {
+			fromIndex = &types.OperationIdentifier{
+				Index: int64(len(ops) + startIndex),
+			}
+			ops = append(ops, &types.Operation{
+				OperationIdentifier: fromIndex,
+				Type:                ERC20TransferOpType,
+				Status:              types.String(SuccessStatus),
+				Account: &types.AccountIdentifier{
+					Address: MustChecksum(from.Hex()),
+				},
+				Amount: &types.Amount{
+					Value:    new(big.Int).Neg(amount).String(),
+					Currency: currency,
+				},
+			})
+		}
+
+		if to == (common.Address{}) {
+			continue
+		}
+
+		toOp := &types.Operation{
+			OperationIdentifier: &types.OperationIdentifier{
+				Index: int64(len(ops) + startIndex),
+			},
+			Type:   ERC20TransferOpType,
+			Status: types.String(SuccessStatus),
+			Account: &types.AccountIdentifier{
+				Address: MustChecksum(to.Hex()),
+			},
+			Amount: &types.Amount{
+				Value:    amount.String(),
+				Currency: currency,
+			},
+		}
+		if fromIndex != nil {
+			toOp.RelatedOperations = []*types.OperationIdentifier{fromIndex}
+		}
+		ops = append(ops, toOp)
+	}
+
+	return ops
+}
+
+// tokenCurrencies returns the *types.Currency of every ERC-20
+// contract that emitted a Transfer event in logs. Contracts that
+// do not implement symbol() and decimals() are omitted.
+func (ec *Client) tokenCurrencies(
+	ctx context.Context,
+	logs []*EthTypes.Log,
+	blockNumber *big.Int,
+) (map[common.Address]*types.Currency, error) {
+	currencies := map[common.Address]*types.Currency{}
+	for _, l := range logs {
+		if _, _, _, ok := parseERC20TransferLog(l); !ok {
+			continue
+		}
+
+		if _, ok := currencies[l.Address]; ok {
+			continue
+		}
+
+		currency, err := ec.tokenCurrency(ctx, l.Address, blockNumber)
+		if err != nil {
+			return nil, fmt.Errorf("%w: unable to get currency of %s", err, l.Address.Hex())
+		}
+
+		if currency != nil {
+			currencies[l.Address] = currency
+		}
+	}
+
+	return currencies, nil
+}
+
+// tokenCurrency returns the *types.Currency of an ERC-20 contract. Results
+// (including contracts that are not tokens) are cached because a token's
+// symbol and decimals never change. Failed calls are not cached.
+func (ec *Client) tokenCurrency(
+	ctx context.Context,
+	address common.Address,
+	blockNumber *big.Int,
+) (*types.Currency, error) {
+	if cached, ok := ec.tokenCache.Load(address); ok {
+		return cached.(*types.Currency), nil
+	}
+
+	symbolData, err := ec.callContract(ctx, address, symbolMethodID, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	decimalsData, err := ec.callContract(ctx, address, decimalsMethodID, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	var currency *types.Currency
+	symbol, symbolOk := decodeABIString(symbolData)
+	decimals, decimalsOk := decodeABIUint8(decimalsData)
+	if symbolOk && decimalsOk {
+		currency = &types.Currency{
+			Symbol:   symbol,
+			Decimals: int32(decimals),
+			Metadata: map[string]interface{}{
+				ContractAddressKey: MustChecksum(address.Hex()),
+			},
+		}
+	} else {
+		log.Printf("%s does not implement ERC-20 metadata, skipping transfers", address.Hex())
+	}
+
+	ec.tokenCache.Store(address, currency)
+	return currency, nil
+}
+
+// tokenBalance returns the balance of account in an ERC-20
+// token at blockNumber, as returned by balanceOf(address).
+func (ec *Client) tokenBalance(
+	ctx context.Context,
+	token common.Address,
+	account common.Address,
+	blockNumber *big.Int,
+) (*big.Int, error) {
+	data := append(append([]byte{}, balanceOfMethodID...), common.LeftPadBytes(account.Bytes(), erc20ArgLength)...)
+	balanceData, err := ec.callContract(ctx, token, data, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(balanceData) != erc20ArgLength {
+		return nil, fmt.Errorf("%w: %s does not implement balanceOf", ErrUnsupportedCurrency, token.Hex())
+	}
+
+	return new(big.Int).SetBytes(balanceData), nil
+}
+
+// callContract calls a contract with data. A call that fails in the
+// EVM (for example a revert) returns nil data and no error, as does
+// a call to an account without code. Other errors are returned.
+func (ec *Client) callContract(
+	ctx context.Context,
+	address common.Address,
+	data []byte,
+	blockNumber *big.Int,
+) ([]byte, error) {
+	callParams := map[string]string{
+		"to":   address.Hex(),
+		"data": hexutil.Encode(data),
+	}
+
+	var resp hexutil.Bytes
+	err := ec.c.CallContext(ctx, &resp, "eth_call", callParams, toBlockNumArg(blockNumber))
+	if isExecutionError(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// isExecutionError returns true if err is an error
+// returned by gmet for a call that failed in the EVM.
+func isExecutionError(err error) bool {
+	var rpcErr rpc.Error
+	if !errors.As(err, &rpcErr) {
+		return false
+	}
+
+	for _, executionErr := range executionErrors {
+		if strings.Contains(err.Error(), executionErr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// decodeABIString decodes an ABI encoded string. Some early tokens
+// return their symbol as bytes32, which is also accepted.
+func decodeABIString(data []byte) (string, bool) {
+	if len(data) == erc20ArgLength {
+		symbol := strings.TrimRight(string(data), "\x00")
+		return symbol, len(symbol) > 0
+	}
+
+	if len(data) < 2*erc20ArgLength {
+		return "", false
+	}
+
+	offset := new(big.Int).SetBytes(data[:erc20ArgLength])
+	if !offset.IsUint64() || offset.Uint64()+erc20ArgLength > uint64(len(data)) {
+		return "", false
+	}
+
+	start := offset.Uint64() + erc20ArgLength
+	length := new(big.Int).SetBytes(data[offset.Uint64():start])
+	if !length.IsUint64() || start+length.Uint64() > uint64(len(data)) {
+		return "", false
+	}
+
+	symbol := string(data[start : start+length.Uint64()])
+	return symbol, len(symbol) > 0
+}
+
+// decodeABIUint8 decodes an ABI encoded uint8.
+func decodeABIUint8(data []byte) (uint8, bool) {
+	if len(data) != erc20ArgLength {
+		return 0, false
+	}
+
+	value := new(big.Int).SetBytes(data)
+	if !value.IsUint64() || value.Uint64() > 255 { // nolint:gomnd
+		return 0, false
+	}
+
+	return uint8(value.Uint64()), true
+}
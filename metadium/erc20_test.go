@@ -0,0 +1,324 @@
+// Copyright 2020 Coinbase, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadium
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	mocks "github.com/metadium/rosetta-metadium/mocks/metadium"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	EthTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/sync/semaphore"
+)
+
+func TestERC20TransferData(t *testing.T) {
+	to := common.HexToAddress("0x57B414a0332B5CaB885a451c2a28a07d1e9b8a8d")
+	data := ERC20TransferData(to, big.NewInt(1000))
+	assert.Equal(
+		t,
+		"0xa9059cbb00000000000000000000000057b414a0332b5cab885a451c2a28a07d1e9b8a8d00000000000000000000000000000000000000000000000000000000000003e8", // nolint
+		hexutil.Encode(data),
+	)
+
+	parsedTo, amount, ok := ParseERC20TransferData(data)
+	assert.True(t, ok)
+	assert.Equal(t, to, parsedTo)
+	assert.Equal(t, big.NewInt(1000), amount)
+
+	_, _, ok = ParseERC20TransferData([]byte{})
+	assert.False(t, ok)
+
+	_, _, ok = ParseERC20TransferData(append([]byte{0x00}, data[1:]...))
+	assert.False(t, ok)
+}
+
+func TestTokenContractAddress(t *testing.T) {
+	address, ok := TokenContractAddress(&types.Currency{
+		Symbol:   "TKN",
+		Decimals: 18,
+		Metadata: map[string]interface{}{
+			ContractAddressKey: "0x2d74530c0c196de44d3906822053bf336f18a16e",
+		},
+	})
+	assert.True(t, ok)
+	assert.Equal(t, "0x2d74530C0C196De44d3906822053bf336F18a16e", address)
+
+	_, ok = TokenContractAddress(Currency)
+	assert.False(t, ok)
+	assert.True(t, IsNativeCurrency(Currency))
+	assert.False(t, IsNativeCurrency(nil))
+}
+
+func transferLog(token common.Address, from common.Address, to common.Address, amount int64) *EthTypes.Log {
+	return &EthTypes.Log{
+		Address: token,
+		Topics: []common.Hash{
+			TransferEventTopic,
+			common.BytesToHash(from.Bytes()),
+			common.BytesToHash(to.Bytes()),
+		},
+		Data: common.LeftPadBytes(big.NewInt(amount).Bytes(), 32),
+	}
+}
+
+func TestERC20Ops(t *testing.T) {
+	token := common.HexToAddress("0x2d74530C0C196De44d3906822053bf336F18a16e")
+	from := common.HexToAddress("0xbe862AD9AbFe6f22BCb087716c7D89a26051f74C")
+	to := common.HexToAddress("0x57B414a0332B5CaB885a451c2a28a07d1e9b8a8d")
+	currency := &types.Currency{
+		Symbol:   "TKN",
+		Decimals: 18,
+		Metadata: map[string]interface{}{
+			ContractAddressKey: token.Hex(),
+		},
+	}
+	nft := transferLog(common.HexToAddress("0x1"), from, to, 1)
+	nft.Topics = append(nft.Topics, common.BigToHash(big.NewInt(1)))
+
+	receipt := &Receipt{
+		Status: EthTypes.ReceiptStatusSuccessful,
+		Logs: []*EthTypes.Log{
+			transferLog(token, from, to, 1000),
+			transferLog(token, common.Address{}, to, 5),
+			transferLog(token, from, to, 0),
+			nft,
+		},
+	}
+	currencies := map[common.Address]*types.Currency{token: currency}
+
+	assert.Equal(t, []*types.Operation{
+		{
+			OperationIdentifier: &types.OperationIdentifier{Index: 2},
+			Type:                ERC20TransferOpType,
+			Status:              types.String(SuccessStatus),
+			Account:             &types.AccountIdentifier{Address: from.Hex()},
+			Amount:              &types.Amount{Value: "-1000", Currency: currency},
+		},
+		{
+			OperationIdentifier: &types.OperationIdentifier{Index: 3},
+			RelatedOperations:   []*types.OperationIdentifier{{Index: 2}},
+			Type:                ERC20TransferOpType,
+			Status:              types.String(SuccessStatus),
+			Account:             &types.AccountIdentifier{Address: to.Hex()},
+			Amount:              &types.Amount{Value: "1000", Currency: currency},
+		},
+		{
+			OperationIdentifier: &types.OperationIdentifier{Index: 4},
+			Type:                ERC20TransferOpType,
+			Status:              types.String(SuccessStatus),
+			Account:             &types.AccountIdentifier{Address: to.Hex()},
+			Amount:              &types.Amount{Value: "5", Currency: currency},
+		},
+	}, erc20Ops(receipt, currencies, 2))
+
+	receipt.Status = EthTypes.ReceiptStatusFailed
+	assert.Empty(t, erc20Ops(receipt, currencies, 2))
+}
+
+func TestTokenCurrency(t *testing.T) {
+	mockJSONRPC := &mocks.JSONRPC{}
+	mockGraphQL := &mocks.GraphQL{}
+
+	c := &Client{
+		c:              mockJSONRPC,
+		g:              mockGraphQL,
+		traceSemaphore: semaphore.NewWeighted(100),
+	}
+
+	ctx := context.Background()
+	token := common.HexToAddress("0x2d74530C0C196De44d3906822053bf336F18a16e")
+	mockJSONRPC.On(
+		"CallContext",
+		ctx,
+		mock.Anything,
+		"eth_call",
+		map[string]string{"to": token.Hex(), "data": hexutil.Encode(symbolMethodID)},
+		"0x64",
+	).Return(
+		nil,
+	).Run(
+		func(args mock.Arguments) {
+			r := args.Get(1).(*hexutil.Bytes)
+
+			*r = hexutil.MustDecode(
+				"0x0000000000000000000000000000000000000000000000000000000000000020" +
+					"0000000000000000000000000000000000000000000000000000000000000003" +
+					"544b4e0000000000000000000000000000000000000000000000000000000000",
+			)
+		},
+	).Once()
+	mockJSONRPC.On(
+		"CallContext",
+		ctx,
+		mock.Anything,
+		"eth_call",
+		map[string]string{"to": token.Hex(), "data": hexutil.Encode(decimalsMethodID)},
+		"0x64",
+	).Return(
+		nil,
+	).Run(
+		func(args mock.Arguments) {
+			r := args.Get(1).(*hexutil.Bytes)
+
+			*r = common.LeftPadBytes([]byte{18}, 32)
+		},
+	).Once()
+
+	currencies, err := c.tokenCurrencies(
+		ctx,
+		[]*EthTypes.Log{
+			transferLog(token, common.HexToAddress("0x1"), common.HexToAddress("0x2"), 1),
+			transferLog(token, common.HexToAddress("0x2"), common.HexToAddress("0x1"), 1),
+		},
+		big.NewInt(100),
+	)
+	assert.NoError(t, err)
+	expected := &types.Currency{
+		Symbol:   "TKN",
+		Decimals: 18,
+		Metadata: map[string]interface{}{
+			ContractAddressKey: token.Hex(),
+		},
+	}
+	assert.Equal(t, map[common.Address]*types.Currency{token: expected}, currencies)
+
+	// Cached currencies do not call gmet again
+	cached, err := c.tokenCurrency(ctx, token, big.NewInt(200))
+	assert.NoError(t, err)
+	assert.Equal(t, expected, cached)
+
+	mockJSONRPC.AssertExpectations(t)
+	mockGraphQL.AssertExpectations(t)
+}
+
+// rpcError is an error returned by gmet.
+type rpcError struct {
+	message string
+}
+
+func (e *rpcError) Error() string  { return e.message }
+func (e *rpcError) ErrorCode() int { return -32000 } // nolint:gomnd
+
+func TestTokenCurrency_CallErrors(t *testing.T) {
+	mockJSONRPC := &mocks.JSONRPC{}
+	mockGraphQL := &mocks.GraphQL{}
+
+	c := &Client{
+		c:              mockJSONRPC,
+		g:              mockGraphQL,
+		traceSemaphore: semaphore.NewWeighted(100),
+	}
+
+	ctx := context.Background()
+	token := common.HexToAddress("0x2d74530C0C196De44d3906822053bf336F18a16e")
+	notToken := common.HexToAddress("0x4dDC2D193948926D02f9B1fE9e1daa0718270ED5")
+	symbolCall := func(address common.Address) map[string]string {
+		return map[string]string{"to": address.Hex(), "data": hexutil.Encode(symbolMethodID)}
+	}
+	decimalsCall := func(address common.Address) map[string]string {
+		return map[string]string{"to": address.Hex(), "data": hexutil.Encode(decimalsMethodID)}
+	}
+
+	// Errors unrelated to the contract are returned and not cached
+	mockJSONRPC.On(
+		"CallContext",
+		ctx,
+		mock.Anything,
+		"eth_call",
+		symbolCall(token),
+		"0x64",
+	).Return(
+		&rpcError{message: "missing trie node 0x1234 (path )"},
+	).Once()
+	currency, err := c.tokenCurrency(ctx, token, big.NewInt(100))
+	assert.Nil(t, currency)
+	assert.EqualError(t, err, "missing trie node 0x1234 (path )")
+
+	mockJSONRPC.On(
+		"CallContext",
+		ctx,
+		mock.Anything,
+		"eth_call",
+		symbolCall(token),
+		"0x64",
+	).Return(
+		nil,
+	).Run(
+		func(args mock.Arguments) {
+			r := args.Get(1).(*hexutil.Bytes)
+
+			*r = common.RightPadBytes([]byte("TKN"), 32)
+		},
+	).Once()
+	mockJSONRPC.On(
+		"CallContext",
+		ctx,
+		mock.Anything,
+		"eth_call",
+		decimalsCall(token),
+		"0x64",
+	).Return(
+		nil,
+	).Run(
+		func(args mock.Arguments) {
+			r := args.Get(1).(*hexutil.Bytes)
+
+			*r = common.LeftPadBytes([]byte{18}, 32)
+		},
+	).Once()
+	currency, err = c.tokenCurrency(ctx, token, big.NewInt(100))
+	assert.NoError(t, err)
+	assert.Equal(t, "TKN", currency.Symbol)
+
+	// Contracts reverting calls are not tokens
+	mockJSONRPC.On(
+		"CallContext",
+		ctx,
+		mock.Anything,
+		"eth_call",
+		symbolCall(notToken),
+		"0x64",
+	).Return(
+		&rpcError{message: "execution reverted"},
+	).Once()
+	mockJSONRPC.On(
+		"CallContext",
+		ctx,
+		mock.Anything,
+		"eth_call",
+		decimalsCall(notToken),
+		"0x64",
+	).Return(
+		&rpcError{message: "execution reverted"},
+	).Once()
+	currency, err = c.tokenCurrency(ctx, notToken, big.NewInt(100))
+	assert.NoError(t, err)
+	assert.Nil(t, currency)
+
+	// Contracts that are not tokens are cached
+	currency, err = c.tokenCurrency(ctx, notToken, big.NewInt(200))
+	assert.NoError(t, err)
+	assert.Nil(t, currency)
+
+	mockJSONRPC.AssertExpectations(t)
+	mockGraphQL.AssertExpectations(t)
+}
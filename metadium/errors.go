@@ -22,4 +22,6 @@ var (
 	ErrCallParametersInvalid = errors.New("call parameters invalid")
 	ErrCallOutputMarshal     = errors.New("call output marshal")
 	ErrCallMethodInvalid     = errors.New("call method invalid")
+	ErrTransactionNotFound   = errors.New("transaction not found")
+	ErrUnsupportedCurrency   = errors.New("unsupported currency")
 )
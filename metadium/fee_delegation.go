@@ -0,0 +1,363 @@
+// Copyright 2020 Coinbase, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadium
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	EthTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// FeeDelegateDynamicFeeTxType is the transaction type of
+// Metadium fee-delegated dynamic fee transactions.
+const FeeDelegateDynamicFeeTxType = 0x16
+
+var (
+	// ErrNotFeeDelegated is returned when decoding a transaction
+	// that is not a fee-delegated transaction.
+	ErrNotFeeDelegated = errors.New("transaction is not fee delegated")
+
+	// ErrFeePayerSignatureInvalid is returned when the fee payer
+	// signature cannot be recovered.
+	ErrFeePayerSignatureInvalid = errors.New("invalid fee payer signature")
+)
+
+// FeeDelegatedTransaction is a Metadium fee-delegated dynamic fee
+// transaction. The sender signs an EIP-1559 transaction and the fee
+// payer signs over the signed sender transaction and its own address.
+// The fee payer is charged the transaction fee.
+//
+// The go-ethereum types do not know about this transaction type,
+// so it is encoded, hashed and signed here as go-metadium does.
+type FeeDelegatedTransaction struct {
+	// SenderTx is the EIP-1559 transaction signed by the sender.
+	SenderTx *EthTypes.Transaction
+	FeePayer common.Address
+
+	// FV, FR and FS are the signature values of the fee payer.
+	FV *big.Int
+	FR *big.Int
+	FS *big.Int
+}
+
+// feeDelegatedTxRLP is the RLP payload that follows the
+// transaction type byte.
+type feeDelegatedTxRLP struct {
+	SenderTx EthTypes.DynamicFeeTx
+	FeePayer *common.Address `rlp:"nil"`
+	FV       *big.Int
+	FR       *big.Int
+	FS       *big.Int
+}
+
+// feeDelegatedTxJSON is the gmet JSON representation
+// of a fee-delegated transaction.
+type feeDelegatedTxJSON struct {
+	Type                 hexutil.Uint64      `json:"type"`
+	ChainID              *hexutil.Big        `json:"chainId"`
+	Nonce                *hexutil.Uint64     `json:"nonce"`
+	MaxPriorityFeePerGas *hexutil.Big        `json:"maxPriorityFeePerGas"`
+	MaxFeePerGas         *hexutil.Big        `json:"maxFeePerGas"`
+	Gas                  *hexutil.Uint64     `json:"gas"`
+	To                   *common.Address     `json:"to"`
+	Value                *hexutil.Big        `json:"value"`
+	Data                 *hexutil.Bytes      `json:"input"`
+	AccessList           EthTypes.AccessList `json:"accessList"`
+	V                    *hexutil.Big        `json:"v"`
+	R                    *hexutil.Big        `json:"r"`
+	S                    *hexutil.Big        `json:"s"`
+	FeePayer             *common.Address     `json:"feePayer"`
+	FV                   *hexutil.Big        `json:"fv"`
+	FR                   *hexutil.Big        `json:"fr"`
+	FS                   *hexutil.Big        `json:"fs"`
+	Hash                 *common.Hash        `json:"hash,omitempty"`
+}
+
+// NewFeeDelegatedTransaction wraps a signed EIP-1559 sender transaction
+// in a fee-delegated transaction that is not yet signed by feePayer.
+func NewFeeDelegatedTransaction(
+	senderTx *EthTypes.Transaction,
+	feePayer common.Address,
+) (*FeeDelegatedTransaction, error) {
+	if senderTx.Type() != EthTypes.DynamicFeeTxType {
+		return nil, fmt.Errorf("sender transaction type %d is not a dynamic fee transaction", senderTx.Type())
+	}
+
+	return &FeeDelegatedTransaction{
+		SenderTx: senderTx,
+		FeePayer: feePayer,
+		FV:       new(big.Int),
+		FR:       new(big.Int),
+		FS:       new(big.Int),
+	}, nil
+}
+
+// IsFeeDelegated returns a boolean indicating if the JSON
+// encoded transaction is a fee-delegated transaction.
+func IsFeeDelegated(input []byte) bool {
+	var typed struct {
+		Type hexutil.Uint64 `json:"type"`
+	}
+	if err := json.Unmarshal(input, &typed); err != nil {
+		return false
+	}
+
+	return typed.Type == FeeDelegateDynamicFeeTxType
+}
+
+// senderTxData returns the fields of the sender transaction,
+// including its signature values.
+func (tx *FeeDelegatedTransaction) senderTxData() EthTypes.DynamicFeeTx {
+	v, r, s := tx.SenderTx.RawSignatureValues()
+	return EthTypes.DynamicFeeTx{
+		ChainID:    tx.SenderTx.ChainId(),
+		Nonce:      tx.SenderTx.Nonce(),
+		GasTipCap:  tx.SenderTx.GasTipCap(),
+		GasFeeCap:  tx.SenderTx.GasFeeCap(),
+		Gas:        tx.SenderTx.Gas(),
+		To:         tx.SenderTx.To(),
+		Value:      tx.SenderTx.Value(),
+		Data:       tx.SenderTx.Data(),
+		AccessList: tx.SenderTx.AccessList(),
+		V:          v,
+		R:          r,
+		S:          s,
+	}
+}
+
+// FeePayerHash returns the hash signed by the fee payer. It
+// commits to the signed sender transaction and the fee payer.
+func (tx *FeeDelegatedTransaction) FeePayerHash() (common.Hash, error) {
+	sender := tx.senderTxData()
+	payload, err := rlp.EncodeToBytes([]interface{}{
+		[]interface{}{
+			sender.ChainID,
+			sender.Nonce,
+			sender.GasTipCap,
+			sender.GasFeeCap,
+			sender.Gas,
+			sender.To,
+			sender.Value,
+			sender.Data,
+			sender.AccessList,
+			sender.V,
+			sender.R,
+			sender.S,
+		},
+		&tx.FeePayer,
+	})
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	return crypto.Keccak256Hash([]byte{FeeDelegateDynamicFeeTxType}, payload), nil
+}
+
+// WithFeePayerSignature returns a copy of the transaction signed
+// by the fee payer. The signature must be in the [R || S || V]
+// format where V is 0 or 1.
+func (tx *FeeDelegatedTransaction) WithFeePayerSignature(
+	sig []byte,
+) (*FeeDelegatedTransaction, error) {
+	if len(sig) != crypto.SignatureLength {
+		return nil, fmt.Errorf(
+			"%w: wrong size for signature: got %d, want %d",
+			ErrFeePayerSignatureInvalid,
+			len(sig),
+			crypto.SignatureLength,
+		)
+	}
+
+	return &FeeDelegatedTransaction{
+		SenderTx: tx.SenderTx,
+		FeePayer: tx.FeePayer,
+		FR:       new(big.Int).SetBytes(sig[:32]),
+		FS:       new(big.Int).SetBytes(sig[32:64]),
+		FV:       new(big.Int).SetBytes([]byte{sig[64]}),
+	}, nil
+}
+
+// Sender returns the address that signed the sender transaction.
+func (tx *FeeDelegatedTransaction) Sender() (common.Address, error) {
+	return EthTypes.Sender(EthTypes.NewLondonSigner(tx.SenderTx.ChainId()), tx.SenderTx)
+}
+
+// FeePayerSender recovers the address that signed as fee payer and
+// ensures it is the fee payer of the transaction.
+func (tx *FeeDelegatedTransaction) FeePayerSender() (common.Address, error) {
+	if !tx.FV.IsUint64() || tx.FV.Uint64() > 1 {
+		return common.Address{}, fmt.Errorf("%w: invalid v %s", ErrFeePayerSignatureInvalid, tx.FV)
+	}
+
+	v := byte(tx.FV.Uint64())
+	if !crypto.ValidateSignatureValues(v, tx.FR, tx.FS, true) {
+		return common.Address{}, ErrFeePayerSignatureInvalid
+	}
+
+	hash, err := tx.FeePayerHash()
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	sig := make([]byte, crypto.SignatureLength)
+	copy(sig[32-len(tx.FR.Bytes()):32], tx.FR.Bytes())
+	copy(sig[64-len(tx.FS.Bytes()):64], tx.FS.Bytes())
+	sig[64] = v
+
+	pub, err := crypto.SigToPub(hash.Bytes(), sig)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("%w: %s", ErrFeePayerSignatureInvalid, err.Error())
+	}
+
+	feePayer := crypto.PubkeyToAddress(*pub)
+	if feePayer != tx.FeePayer {
+		return common.Address{}, fmt.Errorf(
+			"%w: signed by %s instead of %s",
+			ErrFeePayerSignatureInvalid,
+			feePayer.Hex(),
+			tx.FeePayer.Hex(),
+		)
+	}
+
+	return feePayer, nil
+}
+
+// MarshalBinary returns the canonical encoding of the transaction,
+// as sent with eth_sendRawTransaction.
+func (tx *FeeDelegatedTransaction) MarshalBinary() ([]byte, error) {
+	payload, err := rlp.EncodeToBytes(&feeDelegatedTxRLP{
+		SenderTx: tx.senderTxData(),
+		FeePayer: &tx.FeePayer,
+		FV:       tx.FV,
+		FR:       tx.FR,
+		FS:       tx.FS,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte{FeeDelegateDynamicFeeTxType}, payload...), nil
+}
+
+// UnmarshalBinary decodes the canonical encoding of the transaction.
+func (tx *FeeDelegatedTransaction) UnmarshalBinary(b []byte) error {
+	if len(b) == 0 || b[0] != FeeDelegateDynamicFeeTxType {
+		return ErrNotFeeDelegated
+	}
+
+	var dec feeDelegatedTxRLP
+	if err := rlp.DecodeBytes(b[1:], &dec); err != nil {
+		return err
+	}
+	if dec.FeePayer == nil {
+		return errors.New("missing fee payer")
+	}
+
+	tx.SenderTx = EthTypes.NewTx(&dec.SenderTx)
+	tx.FeePayer = *dec.FeePayer
+	tx.FV = dec.FV
+	tx.FR = dec.FR
+	tx.FS = dec.FS
+	return nil
+}
+
+// Hash returns the transaction hash.
+func (tx *FeeDelegatedTransaction) Hash() common.Hash {
+	b, err := tx.MarshalBinary()
+	if err != nil {
+		return common.Hash{}
+	}
+
+	return crypto.Keccak256Hash(b)
+}
+
+// MarshalJSON encodes the transaction in the format used by gmet.
+func (tx *FeeDelegatedTransaction) MarshalJSON() ([]byte, error) {
+	sender := tx.senderTxData()
+	nonce := hexutil.Uint64(sender.Nonce)
+	gas := hexutil.Uint64(sender.Gas)
+	data := hexutil.Bytes(sender.Data)
+	hash := tx.Hash()
+
+	return json.Marshal(&feeDelegatedTxJSON{
+		Type:                 FeeDelegateDynamicFeeTxType,
+		ChainID:              (*hexutil.Big)(sender.ChainID),
+		Nonce:                &nonce,
+		MaxPriorityFeePerGas: (*hexutil.Big)(sender.GasTipCap),
+		MaxFeePerGas:         (*hexutil.Big)(sender.GasFeeCap),
+		Gas:                  &gas,
+		To:                   sender.To,
+		Value:                (*hexutil.Big)(sender.Value),
+		Data:                 &data,
+		AccessList:           sender.AccessList,
+		V:                    (*hexutil.Big)(sender.V),
+		R:                    (*hexutil.Big)(sender.R),
+		S:                    (*hexutil.Big)(sender.S),
+		FeePayer:             &tx.FeePayer,
+		FV:                   (*hexutil.Big)(tx.FV),
+		FR:                   (*hexutil.Big)(tx.FR),
+		FS:                   (*hexutil.Big)(tx.FS),
+		Hash:                 &hash,
+	})
+}
+
+// UnmarshalJSON decodes the transaction from the format used by gmet.
+func (tx *FeeDelegatedTransaction) UnmarshalJSON(input []byte) error {
+	var dec feeDelegatedTxJSON
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+
+	if dec.Type != FeeDelegateDynamicFeeTxType {
+		return ErrNotFeeDelegated
+	}
+
+	if dec.ChainID == nil || dec.Nonce == nil || dec.MaxPriorityFeePerGas == nil ||
+		dec.MaxFeePerGas == nil || dec.Gas == nil || dec.Value == nil || dec.Data == nil ||
+		dec.V == nil || dec.R == nil || dec.S == nil {
+		return errors.New("missing required field in sender transaction")
+	}
+
+	if dec.FeePayer == nil || dec.FV == nil || dec.FR == nil || dec.FS == nil {
+		return errors.New("missing required field in fee payer signature")
+	}
+
+	tx.SenderTx = EthTypes.NewTx(&EthTypes.DynamicFeeTx{
+		ChainID:    (*big.Int)(dec.ChainID),
+		Nonce:      uint64(*dec.Nonce),
+		GasTipCap:  (*big.Int)(dec.MaxPriorityFeePerGas),
+		GasFeeCap:  (*big.Int)(dec.MaxFeePerGas),
+		Gas:        uint64(*dec.Gas),
+		To:         dec.To,
+		Value:      (*big.Int)(dec.Value),
+		Data:       *dec.Data,
+		AccessList: dec.AccessList,
+		V:          (*big.Int)(dec.V),
+		R:          (*big.Int)(dec.R),
+		S:          (*big.Int)(dec.S),
+	})
+	tx.FeePayer = *dec.FeePayer
+	tx.FV = (*big.Int)(dec.FV)
+	tx.FR = (*big.Int)(dec.FR)
+	tx.FS = (*big.Int)(dec.FS)
+	return nil
+}
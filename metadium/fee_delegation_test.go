@@ -0,0 +1,135 @@
+// Copyright 2020 Coinbase, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadium
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	EthTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func testFeeDelegatedTransaction(t *testing.T) (*FeeDelegatedTransaction, common.Address, common.Address) {
+	senderKey, err := crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	assert.NoError(t, err)
+	feePayerKey, err := crypto.HexToECDSA("8a1f9a8f95be41cd7ccb6168179afb4504aefe388d1e14474d32c45c72ce7b7a")
+	assert.NoError(t, err)
+	sender := crypto.PubkeyToAddress(senderKey.PublicKey)
+	feePayer := crypto.PubkeyToAddress(feePayerKey.PublicKey)
+
+	to := common.HexToAddress("0x57B414a0332B5CaB885a451c2a28a07d1e9b8a8d")
+	chainID := big.NewInt(12)
+	senderTx, err := EthTypes.SignNewTx(senderKey, EthTypes.NewLondonSigner(chainID), &EthTypes.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     3,
+		GasTipCap: big.NewInt(1000000000),
+		GasFeeCap: big.NewInt(161000000000),
+		Gas:       21000,
+		To:        &to,
+		Value:     big.NewInt(1000),
+	})
+	assert.NoError(t, err)
+
+	unsignedTx, err := NewFeeDelegatedTransaction(senderTx, feePayer)
+	assert.NoError(t, err)
+
+	feePayerHash, err := unsignedTx.FeePayerHash()
+	assert.NoError(t, err)
+	sig, err := crypto.Sign(feePayerHash.Bytes(), feePayerKey)
+	assert.NoError(t, err)
+
+	tx, err := unsignedTx.WithFeePayerSignature(sig)
+	assert.NoError(t, err)
+
+	return tx, sender, feePayer
+}
+
+func TestFeeDelegatedTransaction_Signers(t *testing.T) {
+	tx, sender, feePayer := testFeeDelegatedTransaction(t)
+
+	recoveredSender, err := tx.Sender()
+	assert.NoError(t, err)
+	assert.Equal(t, sender, recoveredSender)
+
+	recoveredFeePayer, err := tx.FeePayerSender()
+	assert.NoError(t, err)
+	assert.Equal(t, feePayer, recoveredFeePayer)
+
+	// A fee payer signature does not carry over to another fee payer.
+	tx.FeePayer = sender
+	_, err = tx.FeePayerSender()
+	assert.True(t, errors.Is(err, ErrFeePayerSignatureInvalid))
+}
+
+func TestFeeDelegatedTransaction_Encoding(t *testing.T) {
+	tx, _, feePayer := testFeeDelegatedTransaction(t)
+
+	raw, err := tx.MarshalBinary()
+	assert.NoError(t, err)
+	assert.Equal(t, byte(FeeDelegateDynamicFeeTxType), raw[0])
+	assert.Equal(t, crypto.Keccak256Hash(raw), tx.Hash())
+
+	var decoded FeeDelegatedTransaction
+	assert.NoError(t, decoded.UnmarshalBinary(raw))
+	assert.Equal(t, tx.Hash(), decoded.Hash())
+	assert.Equal(t, feePayer, decoded.FeePayer)
+
+	assert.True(t, errors.Is(decoded.UnmarshalBinary([]byte{0x02}), ErrNotFeeDelegated))
+
+	txJSON, err := json.Marshal(tx)
+	assert.NoError(t, err)
+	assert.True(t, IsFeeDelegated(txJSON))
+
+	var fromJSON FeeDelegatedTransaction
+	assert.NoError(t, json.Unmarshal(txJSON, &fromJSON))
+	assert.Equal(t, tx.Hash(), fromJSON.Hash())
+
+	senderJSON, err := tx.SenderTx.MarshalJSON()
+	assert.NoError(t, err)
+	assert.False(t, IsFeeDelegated(senderJSON))
+}
+
+func TestFeeDelegatedTransaction_FeeOps(t *testing.T) {
+	tx, sender, feePayer := testFeeDelegatedTransaction(t)
+
+	txJSON, err := json.Marshal(tx)
+	assert.NoError(t, err)
+
+	// gmet includes the sender in the transaction JSON
+	var txMap map[string]interface{}
+	assert.NoError(t, json.Unmarshal(txJSON, &txMap))
+	txMap["from"] = sender.Hex()
+	txJSON, err = json.Marshal(txMap)
+	assert.NoError(t, err)
+
+	var rpcTx rpcTransaction
+	assert.NoError(t, json.Unmarshal(txJSON, &rpcTx))
+
+	loadedTx := rpcTx.LoadedTransaction()
+	assert.Equal(t, tx.Hash(), loadedTx.Hash)
+	assert.Equal(t, &feePayer, loadedTx.FeePayer)
+	assert.Equal(t, tx.SenderTx.Hash(), loadedTx.Transaction.Hash())
+
+	loadedTx.FeeAmount = big.NewInt(21000)
+	ops := feeOps(loadedTx)
+	assert.Len(t, ops, 1)
+	assert.Equal(t, feePayer.Hex(), ops[0].Account.Address)
+	assert.Equal(t, "-21000", ops[0].Amount.Value)
+}
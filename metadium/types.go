@@ -88,6 +88,10 @@ const (
 	// of a transaction.
 	DestructOpType = "DESTRUCT"
 
+	// ERC20TransferOpType is used to represent the token movements
+	// of ERC-20 Transfer events.
+	ERC20TransferOpType = "ERC20_TRANSFER"
+
 	// SuccessStatus is the status of any
 	// Ethereum operation considered successful.
 	SuccessStatus = "SUCCESS"
@@ -116,6 +120,20 @@ const (
 	// of a transfer.
 	TransferGasLimit = int64(21000) //nolint:gomnd
 
+	// TxPoolPriceBump is the minimum percentage by which gmet
+	// requires the gas price of a replacement transaction to
+	// be raised.
+	TxPoolPriceBump = 10
+
+	// DryRunMethod is the /call method simulating the execution
+	// of a transaction returned by /construction/payloads or
+	// /construction/combine.
+	DryRunMethod = "dry_run"
+
+	// TransactionStatusMethod is the /call method returning the
+	// status of a transaction submitted with /construction/submit.
+	TransactionStatusMethod = "transaction_status"
+
 	// MainnetGmetArguments are the arguments to start a mainnet gmet instance.
 	MainnetGmetArguments = `--config=/app/metadium/gmet.toml --gcmode=archive --graphql`
 
@@ -162,6 +180,7 @@ var (
 		DelegateCallOpType,
 		StaticCallOpType,
 		DestructOpType,
+		ERC20TransferOpType,
 	}
 
 	// OperationStatuses are all supported operation statuses.
@@ -182,6 +201,8 @@ var (
 		"eth_getTransactionReceipt",
 		"eth_call",
 		"eth_estimateGas",
+		DryRunMethod,
+		TransactionStatusMethod,
 	}
 )
 
@@ -8,6 +8,10 @@ import (
 
 	common "github.com/ethereum/go-ethereum/common"
 
+	ethereum "github.com/ethereum/go-ethereum"
+
+	metadium "github.com/metadium/rosetta-metadium/metadium"
+
 	coretypes "github.com/ethereum/go-ethereum/core/types"
 
 	mock "github.com/stretchr/testify/mock"
@@ -20,13 +24,13 @@ type Client struct {
 	mock.Mock
 }
 
-// Balance provides a mock function with given fields: _a0, _a1, _a2
-func (_m *Client) Balance(_a0 context.Context, _a1 *types.AccountIdentifier, _a2 *types.PartialBlockIdentifier) (*types.AccountBalanceResponse, error) {
-	ret := _m.Called(_a0, _a1, _a2)
+// Balance provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *Client) Balance(_a0 context.Context, _a1 *types.AccountIdentifier, _a2 *types.PartialBlockIdentifier, _a3 []*types.Currency) (*types.AccountBalanceResponse, error) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
 
 	var r0 *types.AccountBalanceResponse
-	if rf, ok := ret.Get(0).(func(context.Context, *types.AccountIdentifier, *types.PartialBlockIdentifier) *types.AccountBalanceResponse); ok {
-		r0 = rf(_a0, _a1, _a2)
+	if rf, ok := ret.Get(0).(func(context.Context, *types.AccountIdentifier, *types.PartialBlockIdentifier, []*types.Currency) *types.AccountBalanceResponse); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*types.AccountBalanceResponse)
@@ -34,8 +38,8 @@ func (_m *Client) Balance(_a0 context.Context, _a1 *types.AccountIdentifier, _a2
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *types.AccountIdentifier, *types.PartialBlockIdentifier) error); ok {
-		r1 = rf(_a0, _a1, _a2)
+	if rf, ok := ret.Get(1).(func(context.Context, *types.AccountIdentifier, *types.PartialBlockIdentifier, []*types.Currency) error); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -89,6 +93,165 @@ func (_m *Client) Call(ctx context.Context, request *types.CallRequest) (*types.
 	return r0, r1
 }
 
+// CreateAccessList provides a mock function with given fields: ctx, msg
+func (_m *Client) CreateAccessList(ctx context.Context, msg ethereum.CallMsg) (coretypes.AccessList, error) {
+	ret := _m.Called(ctx, msg)
+
+	var r0 coretypes.AccessList
+	if rf, ok := ret.Get(0).(func(context.Context, ethereum.CallMsg) coretypes.AccessList); ok {
+		r0 = rf(ctx, msg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(coretypes.AccessList)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, ethereum.CallMsg) error); ok {
+		r1 = rf(ctx, msg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DryRun provides a mock function with given fields: ctx, msg
+func (_m *Client) DryRun(ctx context.Context, msg ethereum.CallMsg) (*metadium.DryRun, error) {
+	ret := _m.Called(ctx, msg)
+
+	var r0 *metadium.DryRun
+	if rf, ok := ret.Get(0).(func(context.Context, ethereum.CallMsg) *metadium.DryRun); ok {
+		r0 = rf(ctx, msg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*metadium.DryRun)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, ethereum.CallMsg) error); ok {
+		r1 = rf(ctx, msg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EstimateGas provides a mock function with given fields: ctx, msg
+func (_m *Client) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	ret := _m.Called(ctx, msg)
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func(context.Context, ethereum.CallMsg) uint64); ok {
+		r0 = rf(ctx, msg)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, ethereum.CallMsg) error); ok {
+		r1 = rf(ctx, msg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FeeHistory provides a mock function with given fields: ctx, blockCount, rewardPercentiles
+func (_m *Client) FeeHistory(ctx context.Context, blockCount uint64, rewardPercentiles []float64) (*metadium.FeeHistory, error) {
+	ret := _m.Called(ctx, blockCount, rewardPercentiles)
+
+	var r0 *metadium.FeeHistory
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, []float64) *metadium.FeeHistory); ok {
+		r0 = rf(ctx, blockCount, rewardPercentiles)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*metadium.FeeHistory)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, []float64) error); ok {
+		r1 = rf(ctx, blockCount, rewardPercentiles)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HeaderByNumber provides a mock function with given fields: ctx, number
+func (_m *Client) HeaderByNumber(ctx context.Context, number *big.Int) (*coretypes.Header, error) {
+	ret := _m.Called(ctx, number)
+
+	var r0 *coretypes.Header
+	if rf, ok := ret.Get(0).(func(context.Context, *big.Int) *coretypes.Header); ok {
+		r0 = rf(ctx, number)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.Header)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *big.Int) error); ok {
+		r1 = rf(ctx, number)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Mempool provides a mock function with given fields: _a0
+func (_m *Client) Mempool(_a0 context.Context) ([]*types.TransactionIdentifier, error) {
+	ret := _m.Called(_a0)
+
+	var r0 []*types.TransactionIdentifier
+	if rf, ok := ret.Get(0).(func(context.Context) []*types.TransactionIdentifier); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.TransactionIdentifier)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MempoolTransaction provides a mock function with given fields: _a0, _a1
+func (_m *Client) MempoolTransaction(_a0 context.Context, _a1 *types.TransactionIdentifier) (*types.Transaction, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *types.Transaction
+	if rf, ok := ret.Get(0).(func(context.Context, *types.TransactionIdentifier) *types.Transaction); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Transaction)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *types.TransactionIdentifier) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // PendingNonceAt provides a mock function with given fields: _a0, _a1
 func (_m *Client) PendingNonceAt(_a0 context.Context, _a1 common.Address) (uint64, error) {
 	ret := _m.Called(_a0, _a1)
@@ -110,6 +273,52 @@ func (_m *Client) PendingNonceAt(_a0 context.Context, _a1 common.Address) (uint6
 	return r0, r1
 }
 
+// PendingTransaction provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Client) PendingTransaction(_a0 context.Context, _a1 common.Address, _a2 uint64) (*coretypes.Transaction, common.Hash, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 *coretypes.Transaction
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address, uint64) *coretypes.Transaction); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.Transaction)
+		}
+	}
+
+	var r1 common.Hash
+	if rf, ok := ret.Get(1).(func(context.Context, common.Address, uint64) common.Hash); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(common.Hash)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, common.Address, uint64) error); ok {
+		r2 = rf(_a0, _a1, _a2)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// SendRawTransaction provides a mock function with given fields: ctx, rawTx
+func (_m *Client) SendRawTransaction(ctx context.Context, rawTx []byte) error {
+	ret := _m.Called(ctx, rawTx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) error); ok {
+		r0 = rf(ctx, rawTx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SendTransaction provides a mock function with given fields: ctx, tx
 func (_m *Client) SendTransaction(ctx context.Context, tx *coretypes.Transaction) error {
 	ret := _m.Called(ctx, tx)
@@ -194,3 +403,72 @@ func (_m *Client) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
 
 	return r0, r1
 }
+
+// SuggestGasTipCap provides a mock function with given fields: ctx
+func (_m *Client) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *big.Int
+	if rf, ok := ret.Get(0).(func(context.Context) *big.Int); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*big.Int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Transaction provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Client) Transaction(_a0 context.Context, _a1 *types.BlockIdentifier, _a2 *types.TransactionIdentifier) (*types.Transaction, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 *types.Transaction
+	if rf, ok := ret.Get(0).(func(context.Context, *types.BlockIdentifier, *types.TransactionIdentifier) *types.Transaction); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Transaction)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *types.BlockIdentifier, *types.TransactionIdentifier) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TransactionReceipt provides a mock function with given fields: _a0, _a1
+func (_m *Client) TransactionReceipt(_a0 context.Context, _a1 common.Hash) (*metadium.Receipt, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *metadium.Receipt
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) *metadium.Receipt); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*metadium.Receipt)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, common.Hash) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
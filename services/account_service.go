@@ -16,8 +16,10 @@ package services
 
 import (
 	"context"
+	"errors"
 
 	"github.com/metadium/rosetta-metadium/configuration"
+	"github.com/metadium/rosetta-metadium/metadium"
 
 	"github.com/coinbase/rosetta-sdk-go/types"
 )
@@ -52,7 +54,11 @@ func (s *AccountAPIService) AccountBalance(
 		ctx,
 		request.AccountIdentifier,
 		request.BlockIdentifier,
+		request.Currencies,
 	)
+	if errors.Is(err, metadium.ErrUnsupportedCurrency) {
+		return nil, wrapErr(ErrUnsupportedCurrency, err)
+	}
 	if err != nil {
 		return nil, wrapErr(ErrGmet, err)
 	}
@@ -16,6 +16,7 @@ package services
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/metadium/rosetta-metadium/configuration"
@@ -79,15 +80,34 @@ func TestAccountBalance_Online(t *testing.T) {
 		ctx,
 		account,
 		types.ConstructPartialBlockIdentifier(block),
+		[]*types.Currency{metadium.Currency},
 	).Return(resp, nil).Once()
 
 	bal, err := servicer.AccountBalance(ctx, &types.AccountBalanceRequest{
 		AccountIdentifier: account,
 		BlockIdentifier:   types.ConstructPartialBlockIdentifier(block),
+		Currencies:        []*types.Currency{metadium.Currency},
 	})
 	assert.Nil(t, err)
 	assert.Equal(t, resp, bal)
 
+	unsupported := []*types.Currency{{Symbol: "TKN", Decimals: 18}}
+	mockClient.On(
+		"Balance",
+		ctx,
+		account,
+		types.ConstructPartialBlockIdentifier(block),
+		unsupported,
+	).Return(nil, fmt.Errorf("%w: TKN", metadium.ErrUnsupportedCurrency)).Once()
+
+	bal, err = servicer.AccountBalance(ctx, &types.AccountBalanceRequest{
+		AccountIdentifier: account,
+		BlockIdentifier:   types.ConstructPartialBlockIdentifier(block),
+		Currencies:        unsupported,
+	})
+	assert.Nil(t, bal)
+	assert.Equal(t, ErrUnsupportedCurrency.Code, err.Code)
+
 	coins, err := servicer.AccountCoins(ctx, nil)
 	assert.Nil(t, coins)
 	assert.Equal(t, ErrUnimplemented.Code, err.Code)
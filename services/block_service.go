@@ -68,5 +68,26 @@ func (s *BlockAPIService) BlockTransaction(
 	ctx context.Context,
 	request *types.BlockTransactionRequest,
 ) (*types.BlockTransactionResponse, *types.Error) {
-	return nil, wrapErr(ErrUnimplemented, nil)
+	if s.config.Mode != configuration.Online {
+		return nil, ErrUnavailableOffline
+	}
+
+	transaction, err := s.client.Transaction(
+		ctx,
+		request.BlockIdentifier,
+		request.TransactionIdentifier,
+	)
+	if errors.Is(err, metadium.ErrBlockOrphaned) {
+		return nil, wrapErr(ErrBlockOrphaned, err)
+	}
+	if errors.Is(err, metadium.ErrTransactionNotFound) {
+		return nil, wrapErr(ErrTransactionNotFound, err)
+	}
+	if err != nil {
+		return nil, wrapErr(ErrGmet, err)
+	}
+
+	return &types.BlockTransactionResponse{
+		Transaction: transaction,
+	}, nil
 }
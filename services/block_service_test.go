@@ -41,8 +41,8 @@ func TestBlockService_Offline(t *testing.T) {
 
 	blockTransaction, err := servicer.BlockTransaction(ctx, &types.BlockTransactionRequest{})
 	assert.Nil(t, blockTransaction)
-	assert.Equal(t, ErrUnimplemented.Code, err.Code)
-	assert.Equal(t, ErrUnimplemented.Message, err.Message)
+	assert.Equal(t, ErrUnavailableOffline.Code, err.Code)
+	assert.Equal(t, ErrUnavailableOffline.Message, err.Message)
 
 	mockClient.AssertExpectations(t)
 }
@@ -103,5 +103,51 @@ func TestBlockService_Online(t *testing.T) {
 		assert.Equal(t, ErrBlockOrphaned.Retriable, err.Retriable)
 	})
 
+	transactionIdentifier := &types.TransactionIdentifier{
+		Hash: "tx 1",
+	}
+	transaction := &types.Transaction{
+		TransactionIdentifier: transactionIdentifier,
+	}
+
+	t.Run("block transaction", func(t *testing.T) {
+		mockClient.On(
+			"Transaction",
+			ctx,
+			block.BlockIdentifier,
+			transactionIdentifier,
+		).Return(
+			transaction,
+			nil,
+		).Once()
+		tx, err := servicer.BlockTransaction(ctx, &types.BlockTransactionRequest{
+			BlockIdentifier:       block.BlockIdentifier,
+			TransactionIdentifier: transactionIdentifier,
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, &types.BlockTransactionResponse{
+			Transaction: transaction,
+		}, tx)
+	})
+
+	t.Run("transaction not found", func(t *testing.T) {
+		mockClient.On(
+			"Transaction",
+			ctx,
+			block.BlockIdentifier,
+			transactionIdentifier,
+		).Return(
+			nil,
+			metadium.ErrTransactionNotFound,
+		).Once()
+		tx, err := servicer.BlockTransaction(ctx, &types.BlockTransactionRequest{
+			BlockIdentifier:       block.BlockIdentifier,
+			TransactionIdentifier: transactionIdentifier,
+		})
+		assert.Nil(t, tx)
+		assert.Equal(t, ErrTransactionNotFound.Code, err.Code)
+		assert.Equal(t, ErrTransactionNotFound.Message, err.Message)
+	})
+
 	mockClient.AssertExpectations(t)
 }
@@ -16,25 +16,37 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 
 	"github.com/metadium/rosetta-metadium/configuration"
 	"github.com/metadium/rosetta-metadium/metadium"
 
 	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
 )
 
 // CallAPIService implements the server.CallAPIServicer interface.
 type CallAPIService struct {
-	config *configuration.Configuration
-	client Client
+	config  *configuration.Configuration
+	client  Client
+	tracker *TransactionTracker
 }
 
 // NewCallAPIService creates a new instance of a CallAPIService.
-func NewCallAPIService(cfg *configuration.Configuration, client Client) *CallAPIService {
+func NewCallAPIService(
+	cfg *configuration.Configuration,
+	client Client,
+	tracker *TransactionTracker,
+) *CallAPIService {
 	return &CallAPIService{
-		config: cfg,
-		client: client,
+		config:  cfg,
+		client:  client,
+		tracker: tracker,
 	}
 }
 
@@ -47,6 +59,13 @@ func (s *CallAPIService) Call(
 		return nil, ErrUnavailableOffline
 	}
 
+	switch request.Method {
+	case metadium.DryRunMethod:
+		return s.dryRun(ctx, request.Parameters)
+	case metadium.TransactionStatusMethod:
+		return s.transactionStatus(ctx, request.Parameters)
+	}
+
 	response, err := s.client.Call(ctx, request)
 	if errors.Is(err, metadium.ErrCallParametersInvalid) {
 		return nil, wrapErr(ErrCallParametersInvalid, err)
@@ -63,3 +82,156 @@ func (s *CallAPIService) Call(
 
 	return response, nil
 }
+
+// dryRunParameters are the parameters of the dry_run method.
+type dryRunParameters struct {
+	// Transaction is the unsigned transaction returned by
+	// /construction/payloads or, if Signed is set, the signed
+	// transaction returned by /construction/combine.
+	Transaction string `json:"transaction"`
+	Signed      bool   `json:"signed"`
+}
+
+// dryRun traces the execution of a transaction on top of the
+// latest block and returns the operations it would cause, its
+// gas used and its revert reason, without broadcasting it.
+func (s *CallAPIService) dryRun(
+	ctx context.Context,
+	parameters map[string]interface{},
+) (*types.CallResponse, *types.Error) {
+	var input dryRunParameters
+	if err := types.UnmarshalMap(parameters, &input); err != nil {
+		return nil, wrapErr(ErrCallParametersInvalid, err)
+	}
+
+	if len(input.Transaction) == 0 {
+		return nil, wrapErr(ErrCallParametersInvalid, errors.New("transaction is missing from parameters"))
+	}
+
+	if isBatchTransaction(input.Transaction) {
+		return nil, wrapErr(ErrCallParametersInvalid, errors.New("batches cannot be dry run"))
+	}
+
+	msg, err := dryRunMessage([]byte(input.Transaction), input.Signed)
+	if err != nil {
+		return nil, wrapErr(ErrCallParametersInvalid, err)
+	}
+
+	dryRun, err := s.client.DryRun(ctx, msg)
+	if err != nil {
+		return nil, wrapErr(ErrGmet, err)
+	}
+
+	result, err := types.MarshalMap(dryRun)
+	if err != nil {
+		return nil, wrapErr(ErrCallOutputMarshal, err)
+	}
+
+	return &types.CallResponse{
+		Result: result,
+	}, nil
+}
+
+// dryRunMessage returns the message executing a signed or unsigned
+// transaction. The fee payer of a fee-delegated transaction pays its
+// fee, so its message has no gas price and only needs the sender to
+// afford the value.
+func dryRunMessage(data []byte, signed bool) (ethereum.CallMsg, error) {
+	var (
+		tx           *ethTypes.Transaction
+		msg          ethereum.CallMsg
+		feeDelegated bool
+	)
+	if !signed {
+		var unsignedTx transaction
+		if err := json.Unmarshal(data, &unsignedTx); err != nil {
+			return msg, fmt.Errorf("unable to parse unsigned transaction: %w", err)
+		}
+
+		from, ok := metadium.ChecksumAddress(unsignedTx.From)
+		if !ok {
+			return msg, fmt.Errorf("%s is not a valid address", unsignedTx.From)
+		}
+
+		tx = newEthTransaction(&unsignedTx)
+		msg.From = common.HexToAddress(from)
+		feeDelegated = unsignedTx.isFeeDelegated()
+	} else {
+		signedTx, feeDelegatedTx, err := unmarshalSignedTransaction(data)
+		if err != nil {
+			return msg, fmt.Errorf("unable to parse signed transaction: %w", err)
+		}
+
+		sender, err := ethTypes.Sender(ethTypes.NewLondonSigner(signedTx.ChainId()), signedTx)
+		if err != nil {
+			return msg, fmt.Errorf("unable to recover sender: %w", err)
+		}
+
+		tx = signedTx
+		msg.From = sender
+		feeDelegated = feeDelegatedTx != nil
+	}
+
+	msg.To = tx.To()
+	msg.Gas = tx.Gas()
+	msg.Value = tx.Value()
+	msg.Data = tx.Data()
+	msg.AccessList = tx.AccessList()
+	if feeDelegated {
+		return msg, nil
+	}
+
+	if tx.Type() == ethTypes.DynamicFeeTxType {
+		msg.GasFeeCap = tx.GasFeeCap()
+		msg.GasTipCap = tx.GasTipCap()
+	} else {
+		msg.GasPrice = tx.GasPrice()
+	}
+
+	return msg, nil
+}
+
+// transactionStatusParameters are the parameters
+// of the transaction_status method.
+type transactionStatusParameters struct {
+	Hash string `json:"hash"`
+}
+
+// transactionStatus returns the status of a transaction
+// submitted with /construction/submit.
+func (s *CallAPIService) transactionStatus(
+	ctx context.Context,
+	parameters map[string]interface{},
+) (*types.CallResponse, *types.Error) {
+	var input transactionStatusParameters
+	if err := types.UnmarshalMap(parameters, &input); err != nil {
+		return nil, wrapErr(ErrCallParametersInvalid, err)
+	}
+
+	hash, err := hexutil.Decode(input.Hash)
+	if err != nil || len(hash) != common.HashLength {
+		return nil, wrapErr(ErrCallParametersInvalid, fmt.Errorf("%s is not a valid hash", input.Hash))
+	}
+
+	tx := s.tracker.get(common.BytesToHash(hash))
+	if tx == nil {
+		return nil, wrapErr(
+			ErrTransactionNotFound,
+			fmt.Errorf("%s was not submitted with /construction/submit", input.Hash),
+		)
+	}
+
+	status, err := s.tracker.status(ctx, tx)
+	if err != nil {
+		return nil, wrapErr(ErrGmet, err)
+	}
+
+	result, err := types.MarshalMap(status)
+	if err != nil {
+		return nil, wrapErr(ErrCallOutputMarshal, err)
+	}
+
+	return &types.CallResponse{
+		Result: result,
+	}, nil
+}
@@ -16,12 +16,20 @@ package services
 
 import (
 	"context"
+	"encoding/json"
+	"math/big"
 	"testing"
 
 	"github.com/metadium/rosetta-metadium/configuration"
+	"github.com/metadium/rosetta-metadium/metadium"
 	mocks "github.com/metadium/rosetta-metadium/mocks/services"
 
 	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -30,7 +38,7 @@ func TestCall_Offline(t *testing.T) {
 		Mode: configuration.Offline,
 	}
 	mockClient := &mocks.Client{}
-	servicer := NewCallAPIService(cfg, mockClient)
+	servicer := NewCallAPIService(cfg, mockClient, NewTransactionTracker(cfg, mockClient))
 	ctx := context.Background()
 
 	resp, err := servicer.Call(ctx, &types.CallRequest{})
@@ -45,7 +53,7 @@ func TestCall_Online(t *testing.T) {
 		Mode: configuration.Online,
 	}
 	mockClient := &mocks.Client{}
-	servicer := NewCallAPIService(cfg, mockClient)
+	servicer := NewCallAPIService(cfg, mockClient, NewTransactionTracker(cfg, mockClient))
 	ctx := context.Background()
 
 	request := &types.CallRequest{
@@ -65,3 +73,119 @@ func TestCall_Online(t *testing.T) {
 
 	mockClient.AssertExpectations(t)
 }
+
+func TestCall_DryRun(t *testing.T) {
+	cfg := &configuration.Configuration{
+		Mode: configuration.Online,
+	}
+	mockClient := &mocks.Client{}
+	servicer := NewCallAPIService(cfg, mockClient, NewTransactionTracker(cfg, mockClient))
+	ctx := context.Background()
+
+	privateKey, keyErr := crypto.HexToECDSA(
+		"b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291",
+	)
+	assert.NoError(t, keyErr)
+
+	from := common.HexToAddress("0x71562b71999873DB5b286dF957af199Ec94617F7")
+	to := common.HexToAddress("0x57B414a0332B5CaB885a451c2a28a07d1e9b8a8d")
+	unsignedTx := &transaction{
+		From:     from.Hex(),
+		To:       to.Hex(),
+		Value:    big.NewInt(1000),
+		Data:     []byte{},
+		Nonce:    3,
+		GasPrice: big.NewInt(80000000000),
+		GasLimit: 21000,
+		ChainID:  params.MetadiumTestnetChainConfig.ChainID,
+	}
+	unsignedTxJSON, err := json.Marshal(unsignedTx)
+	assert.NoError(t, err)
+
+	signedTx, err := ethTypes.SignTx(
+		newEthTransaction(unsignedTx),
+		ethTypes.NewLondonSigner(unsignedTx.ChainID),
+		privateKey,
+	)
+	assert.NoError(t, err)
+	signedTxJSON, err := signedTx.MarshalJSON()
+	assert.NoError(t, err)
+
+	msg := ethereum.CallMsg{
+		From:     from,
+		To:       &to,
+		Gas:      21000,
+		GasPrice: big.NewInt(80000000000),
+		Value:    big.NewInt(1000),
+		Data:     []byte{},
+	}
+	dryRun := &metadium.DryRun{
+		Operations: []*types.Operation{
+			{
+				OperationIdentifier: &types.OperationIdentifier{Index: 0},
+				Type:                metadium.CallOpType,
+				Status:              types.String(metadium.SuccessStatus),
+				Account:             &types.AccountIdentifier{Address: from.Hex()},
+				Amount:              &types.Amount{Value: "-1000", Currency: metadium.Currency},
+			},
+			{
+				OperationIdentifier: &types.OperationIdentifier{Index: 1},
+				RelatedOperations:   []*types.OperationIdentifier{{Index: 0}},
+				Type:                metadium.CallOpType,
+				Status:              types.String(metadium.SuccessStatus),
+				Account:             &types.AccountIdentifier{Address: to.Hex()},
+				Amount:              &types.Amount{Value: "1000", Currency: metadium.Currency},
+			},
+		},
+		GasUsed: 21000,
+	}
+	result, err := types.MarshalMap(dryRun)
+	assert.NoError(t, err)
+
+	tests := map[string]struct {
+		transaction string
+		signed      bool
+	}{
+		"unsigned": {
+			transaction: string(unsignedTxJSON),
+		},
+		"signed": {
+			transaction: string(signedTxJSON),
+			signed:      true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockClient.On("DryRun", ctx, msg).Return(dryRun, nil).Once()
+			callResp, err := servicer.Call(ctx, &types.CallRequest{
+				Method: metadium.DryRunMethod,
+				Parameters: map[string]interface{}{
+					"transaction": test.transaction,
+					"signed":      test.signed,
+				},
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, &types.CallResponse{Result: result}, callResp)
+		})
+	}
+
+	// Batches cannot be dry run
+	callResp, rErr := servicer.Call(ctx, &types.CallRequest{
+		Method: metadium.DryRunMethod,
+		Parameters: map[string]interface{}{
+			"transaction": "[" + string(unsignedTxJSON) + "]",
+		},
+	})
+	assert.Nil(t, callResp)
+	assert.Equal(t, ErrCallParametersInvalid.Code, rErr.Code)
+
+	// Missing transaction
+	callResp, rErr = servicer.Call(ctx, &types.CallRequest{
+		Method: metadium.DryRunMethod,
+	})
+	assert.Nil(t, callResp)
+	assert.Equal(t, ErrCallParametersInvalid.Code, rErr.Code)
+
+	mockClient.AssertExpectations(t)
+}
@@ -0,0 +1,458 @@
+// Copyright 2020 Coinbase, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+)
+
+// A batch is a list of transfers made by the same sender with
+// consecutive nonces. Its operations are the pairs of operations
+// of each transfer, in order, and its unsigned and signed
+// transactions are JSON arrays of the transactions of each
+// transfer.
+
+const (
+	// transactionHashesKey is the metadata key holding
+	// the hashes of the transactions of a batch.
+	transactionHashesKey = "transaction_hashes"
+
+	// transactionsKey is the metadata key holding the
+	// parse metadata of the transactions of a batch.
+	transactionsKey = "transactions"
+
+	// submittedKey is the error detail key holding the number of
+	// transactions of a batch submitted before a broadcast failed.
+	submittedKey = "submitted"
+
+	transferOperations = 2
+)
+
+// isBatch returns true if operations describe a batch of transfers,
+// that is, more than one pair of operations debiting a sender and
+// crediting a recipient. Other operations are left to be rejected
+// as a single transfer.
+func isBatch(operations []*types.Operation) bool {
+	if len(operations) <= transferOperations || len(operations)%transferOperations != 0 {
+		return false
+	}
+
+	for i := 0; i < len(operations); i += transferOperations {
+		if !isTransferPair(operations[i], operations[i+1]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isTransferPair returns true if from debits an account
+// and to credits an account with the same currency.
+func isTransferPair(from *types.Operation, to *types.Operation) bool {
+	if from.Account == nil || to.Account == nil || from.Amount == nil || to.Amount == nil {
+		return false
+	}
+
+	if types.Hash(from.Amount.Currency) != types.Hash(to.Amount.Currency) {
+		return false
+	}
+
+	fromAmount, err := types.AmountValue(from.Amount)
+	if err != nil || fromAmount.Sign() >= 0 {
+		return false
+	}
+
+	toAmount, err := types.AmountValue(to.Amount)
+	if err != nil || toAmount.Sign() <= 0 {
+		return false
+	}
+
+	return true
+}
+
+// isBatchTransaction returns true if tx is the unsigned or
+// signed transaction of a batch.
+func isBatchTransaction(tx string) bool {
+	return strings.HasPrefix(strings.TrimSpace(tx), "[")
+}
+
+// parseBatchIntent parses the intents of the transfers of a batch.
+func parseBatchIntent(operations []*types.Operation) ([]*transferIntent, *types.Error) {
+	if len(operations)%transferOperations != 0 {
+		return nil, wrapErr(
+			ErrUnclearIntent,
+			fmt.Errorf("a batch must have %d operations per transfer", transferOperations),
+		)
+	}
+
+	intents := make([]*transferIntent, 0, len(operations)/transferOperations)
+	for i := 0; i < len(operations); i += transferOperations {
+		intent, rErr := parseTransferIntent(operations[i : i+transferOperations])
+		if rErr != nil {
+			return nil, rErr
+		}
+		if intent.Create {
+			return nil, wrapErr(ErrUnclearIntent, errors.New("a batch can only contain transfers"))
+		}
+		if len(intents) > 0 && intent.From != intents[0].From {
+			return nil, wrapErr(ErrUnclearIntent, errors.New("all transfers of a batch must have the same sender"))
+		}
+
+		intents = append(intents, intent)
+	}
+
+	return intents, nil
+}
+
+// preprocessBatch implements /construction/preprocess for a batch.
+// The options of each transfer are passed through so their gas
+// usage can be estimated.
+func preprocessBatch(
+	request *types.ConstructionPreprocessRequest,
+) (*types.ConstructionPreprocessResponse, *types.Error) {
+	intents, rErr := parseBatchIntent(request.Operations)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	var input preprocessMetadata
+	if err := unmarshalJSONMap(request.Metadata, &input); err != nil {
+		return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
+	}
+
+	if len(input.FeePayer) > 0 || input.Replace || input.Cancel ||
+		input.contractCall.isSet() || input.contractCreation.isSet() ||
+		len(input.AccessList) > 0 || input.CreateAccessList {
+		return nil, wrapErr(ErrUnclearIntent, errors.New("a batch can only contain transfers"))
+	}
+
+	preprocessOutput := &options{
+		From: intents[0].From,
+	}
+	if input.Nonce != nil {
+		nonce := uint64(*input.Nonce)
+		preprocessOutput.Nonce = &nonce
+	}
+
+	if len(input.FeeTier) > 0 && !input.FeeTier.isValid() {
+		return nil, wrapErr(ErrInvalidFeeTier, fmt.Errorf("%s is not a valid fee tier", input.FeeTier))
+	}
+	preprocessOutput.FeeTier = input.FeeTier
+	preprocessOutput.RawTransaction = input.RawTransaction
+	if input.RawTransaction {
+		for _, intent := range intents {
+			if rErr := checkRawTransaction(intent, ""); rErr != nil {
+				return nil, rErr
+			}
+		}
+	}
+
+	if !isSupportedSignatureType(input.SignatureType) {
+		return nil, wrapErr(
+			ErrUnsupportedSignatureType,
+			fmt.Errorf("%s is not a supported signature type", input.SignatureType),
+		)
+	}
+	preprocessOutput.SignatureType = input.SignatureType
+
+	for _, intent := range intents {
+		to, value, data := intent.txFields()
+		transfer := &options{
+			From: intent.From,
+			To:   to,
+			Data: data,
+		}
+		if value.Sign() > 0 {
+			transfer.Value = value
+		}
+
+		preprocessOutput.Batch = append(preprocessOutput.Batch, transfer)
+	}
+
+	marshaled, err := marshalJSONMap(preprocessOutput)
+	if err != nil {
+		return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
+	}
+
+	return &types.ConstructionPreprocessResponse{
+		Options: marshaled,
+	}, nil
+}
+
+// payloadsBatch implements /construction/payloads for a batch. The
+// transactions share the fees and gas limit of the metadata and
+// use consecutive nonces, starting with the nonce of the metadata.
+func (s *ConstructionAPIService) payloadsBatch(
+	request *types.ConstructionPayloadsRequest,
+) (*types.ConstructionPayloadsResponse, *types.Error) {
+	intents, rErr := parseBatchIntent(request.Operations)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	var metadata metadata
+	if err := unmarshalJSONMap(request.Metadata, &metadata); err != nil {
+		return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
+	}
+
+	if len(metadata.FeePayer) > 0 || len(metadata.Data) > 0 || len(metadata.MethodSignature) > 0 {
+		return nil, wrapErr(ErrUnclearIntent, errors.New("a batch can only contain transfers"))
+	}
+
+	unsignedTxs := make([]*transaction, len(intents))
+	payloads := make([]*types.SigningPayload, 0, len(intents))
+	for i, intent := range intents {
+		unsignedTx, rErr := s.newUnsignedTransaction(intent, &metadata, metadata.Nonce+uint64(i))
+		if rErr != nil {
+			return nil, rErr
+		}
+
+		txPayloads, rErr := signingPayloads(unsignedTx)
+		if rErr != nil {
+			return nil, rErr
+		}
+
+		unsignedTxs[i] = unsignedTx
+		payloads = append(payloads, txPayloads...)
+	}
+
+	unsignedTxsJSON, err := json.Marshal(unsignedTxs)
+	if err != nil {
+		return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
+	}
+
+	return &types.ConstructionPayloadsResponse{
+		UnsignedTransaction: string(unsignedTxsJSON),
+		Payloads:            payloads,
+	}, nil
+}
+
+// combineBatch implements /construction/combine for a batch. The
+// signatures must be in the order of the payloads.
+func (s *ConstructionAPIService) combineBatch(
+	request *types.ConstructionCombineRequest,
+) (*types.ConstructionCombineResponse, *types.Error) {
+	var unsignedTxs []*transaction
+	if err := json.Unmarshal([]byte(request.UnsignedTransaction), &unsignedTxs); err != nil {
+		return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
+	}
+
+	if len(request.Signatures) != len(unsignedTxs) {
+		return nil, wrapErr(
+			ErrSignatureInvalid,
+			fmt.Errorf("expected %d signatures, got %d signatures", len(unsignedTxs), len(request.Signatures)),
+		)
+	}
+
+	signedTxs := make([]json.RawMessage, len(unsignedTxs))
+	for i, unsignedTx := range unsignedTxs {
+		if unsignedTx.isFeeDelegated() {
+			return nil, wrapErr(ErrUnclearIntent, errors.New("a batch can only contain transfers"))
+		}
+
+		if rErr := s.checkChainID(unsignedTx.ChainID); rErr != nil {
+			return nil, rErr
+		}
+
+		if rErr := s.checkCaps(newEthTransaction(unsignedTx)); rErr != nil {
+			return nil, rErr
+		}
+
+		signedTxJSON, rErr := combineTransaction(unsignedTx, request.Signatures[i:i+1])
+		if rErr != nil {
+			return nil, rErr
+		}
+
+		// Raw transactions are hex strings in the batch.
+		if unsignedTx.RawTransaction {
+			quoted, err := json.Marshal(string(signedTxJSON))
+			if err != nil {
+				return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
+			}
+			signedTxJSON = quoted
+		}
+		signedTxs[i] = signedTxJSON
+	}
+
+	signedTxsJSON, err := json.Marshal(signedTxs)
+	if err != nil {
+		return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
+	}
+
+	return &types.ConstructionCombineResponse{
+		SignedTransaction: string(signedTxsJSON),
+	}, nil
+}
+
+// hashBatch implements /construction/hash for a batch. The
+// transaction identifier is the hash of the first transaction,
+// and the hashes of all of them are returned in the metadata.
+func hashBatch(
+	request *types.ConstructionHashRequest,
+) (*types.TransactionIdentifierResponse, *types.Error) {
+	signedTxs, rErr := unmarshalBatch(request.SignedTransaction)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	hashes := make([]string, len(signedTxs))
+	for i, signedTx := range signedTxs {
+		hash, err := signedTransactionHash(signedTx)
+		if err != nil {
+			return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
+		}
+		hashes[i] = hash.Hex()
+	}
+
+	return batchIdentifier(hashes), nil
+}
+
+// parseBatch implements /construction/parse for a batch. The
+// operations of each transaction are appended in order, and the
+// metadata of each transaction is returned in the metadata.
+func (s *ConstructionAPIService) parseBatch(
+	request *types.ConstructionParseRequest,
+) (*types.ConstructionParseResponse, *types.Error) {
+	txs, rErr := unmarshalBatch(request.Transaction)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	ops := []*types.Operation{}
+	signers := []*types.AccountIdentifier{}
+	txsMetadata := make([]map[string]interface{}, len(txs))
+	seen := map[string]struct{}{}
+	for i, tx := range txs {
+		txOps, metadata, txSigners, rErr := s.parseTransaction(tx, request.Signed)
+		if rErr != nil {
+			return nil, rErr
+		}
+
+		offset := int64(len(ops))
+		for _, op := range txOps {
+			op.OperationIdentifier.Index += offset
+			for _, related := range op.RelatedOperations {
+				related.Index += offset
+			}
+		}
+		ops = append(ops, txOps...)
+
+		for _, signer := range txSigners {
+			if _, ok := seen[signer.Address]; ok {
+				continue
+			}
+			seen[signer.Address] = struct{}{}
+			signers = append(signers, signer)
+		}
+
+		metaMap, err := marshalJSONMap(metadata)
+		if err != nil {
+			return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
+		}
+		txsMetadata[i] = metaMap
+	}
+
+	return &types.ConstructionParseResponse{
+		Operations:               ops,
+		AccountIdentifierSigners: signers,
+		Metadata: map[string]interface{}{
+			transactionsKey: txsMetadata,
+		},
+	}, nil
+}
+
+// submitBatch implements /construction/submit for a batch. The
+// transactions are broadcast in order, stopping at the first
+// failure. The number of transactions broadcast before the
+// failure is returned in the error details, and the nonces of
+// the others are released.
+func (s *ConstructionAPIService) submitBatch(
+	ctx context.Context,
+	request *types.ConstructionSubmitRequest,
+) (*types.TransactionIdentifierResponse, *types.Error) {
+	signedTxs, rErr := unmarshalBatch(request.SignedTransaction)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	hashes := make([]string, len(signedTxs))
+	for i, signedTx := range signedTxs {
+		hash, rErr := s.submitTransaction(ctx, signedTx)
+		if rErr != nil {
+			// The remaining transactions will not be
+			// broadcast, so their nonces are released.
+			for _, remaining := range signedTxs[i+1:] {
+				remainingTx, _, err := unmarshalSignedTransaction(remaining)
+				if err == nil {
+					s.releaseNonce(remainingTx)
+				}
+			}
+
+			if rErr.Details == nil {
+				rErr.Details = map[string]interface{}{}
+			}
+			rErr.Details[submittedKey] = i
+			return nil, rErr
+		}
+		hashes[i] = hash.Hex()
+	}
+
+	return batchIdentifier(hashes), nil
+}
+
+// unmarshalBatch splits the JSON array of a batch into its
+// transactions. Raw transactions are hex strings in the array.
+func unmarshalBatch(batch string) ([][]byte, *types.Error) {
+	var elements []json.RawMessage
+	if err := json.Unmarshal([]byte(batch), &elements); err != nil {
+		return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
+	}
+
+	if len(elements) == 0 {
+		return nil, wrapErr(ErrUnableToParseIntermediateResult, errors.New("batch is empty"))
+	}
+
+	txs := make([][]byte, len(elements))
+	for i, element := range elements {
+		var rawTx string
+		if err := json.Unmarshal(element, &rawTx); err == nil {
+			txs[i] = []byte(rawTx)
+			continue
+		}
+
+		txs[i] = element
+	}
+
+	return txs, nil
+}
+
+// batchIdentifier returns the identifier of a batch
+// with the provided transaction hashes.
+func batchIdentifier(hashes []string) *types.TransactionIdentifierResponse {
+	return &types.TransactionIdentifierResponse{
+		TransactionIdentifier: &types.TransactionIdentifier{
+			Hash: hashes[0],
+		},
+		Metadata: map[string]interface{}{
+			transactionHashesKey: hashes,
+		},
+	}
+}
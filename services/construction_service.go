@@ -15,17 +15,20 @@
 package services
 
 import (
+	"bytes"
 	"context"
 	"crypto/ecdsa"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
-	"strconv"
 
 	"github.com/metadium/rosetta-metadium/configuration"
 	"github.com/metadium/rosetta-metadium/metadium"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	ethTypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 
@@ -35,18 +38,25 @@ import (
 
 // ConstructionAPIService implements the server.ConstructionAPIServicer interface.
 type ConstructionAPIService struct {
-	config *configuration.Configuration
-	client Client
+	config  *configuration.Configuration
+	client  Client
+	nonces  *nonceManager
+	fees    *feeEstimator
+	tracker *TransactionTracker
 }
 
 // NewConstructionAPIService creates a new instance of a ConstructionAPIService.
 func NewConstructionAPIService(
 	cfg *configuration.Configuration,
 	client Client,
+	tracker *TransactionTracker,
 ) *ConstructionAPIService {
 	return &ConstructionAPIService{
-		config: cfg,
-		client: client,
+		config:  cfg,
+		client:  client,
+		nonces:  newNonceManager(cfg.NonceTTL),
+		fees:    newFeeEstimator(client),
+		tracker: tracker,
 	}
 }
 
@@ -55,23 +65,73 @@ func (s *ConstructionAPIService) ConstructionDerive(
 	ctx context.Context,
 	request *types.ConstructionDeriveRequest,
 ) (*types.ConstructionDeriveResponse, *types.Error) {
-	var pubkey *ecdsa.PublicKey
-	var err error
-	if len(request.PublicKey.Bytes) != 33 {
-		pubkey, err = crypto.UnmarshalPubkey(request.PublicKey.Bytes)
-	} else {
-		pubkey, err = crypto.DecompressPubkey(request.PublicKey.Bytes)
+	pubkey, err := parsePublicKey(request.PublicKey.Bytes)
+	if err != nil {
+		return nil, wrapErr(ErrUnableToDecompressPubkey, err)
+	}
+
+	var input deriveMetadata
+	if err := unmarshalJSONMap(request.Metadata, &input); err != nil {
+		return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
+	}
+
+	if len(input.XPub) == 0 {
+		if len(input.DerivationPath) > 0 {
+			return nil, wrapErr(ErrInvalidDerivationPath, errors.New("no xpub provided"))
+		}
+
+		addr := crypto.PubkeyToAddress(*pubkey)
+		return &types.ConstructionDeriveResponse{
+			AccountIdentifier: &types.AccountIdentifier{
+				Address: addr.Hex(),
+			},
+		}, nil
 	}
 
+	return deriveExtendedPublicKey(pubkey, &input)
+}
+
+// deriveExtendedPublicKey derives the account of the key at the
+// derivation path of input from its xpub. The public key of the
+// request must be the key of the xpub. The derived public key is
+// returned in the metadata of the response.
+func deriveExtendedPublicKey(
+	pubkey *ecdsa.PublicKey,
+	input *deriveMetadata,
+) (*types.ConstructionDeriveResponse, *types.Error) {
+	xpub, err := parseExtendedPublicKey(input.XPub)
 	if err != nil {
-		return nil, wrapErr(ErrUnableToDecompressPubkey, err)
+		return nil, wrapErr(ErrInvalidExtendedPublicKey, err)
+	}
+
+	if !xpub.key.Equal(pubkey) {
+		return nil, wrapErr(
+			ErrInvalidExtendedPublicKey,
+			errors.New("public key is not the key of the xpub"),
+		)
+	}
+
+	child, err := xpub.derivePath(input.DerivationPath)
+	if err != nil {
+		return nil, wrapErr(ErrInvalidDerivationPath, err)
+	}
+
+	metadata, err := marshalJSONMap(map[string]interface{}{
+		"public_key": &types.PublicKey{
+			Bytes:     crypto.CompressPubkey(child.key),
+			CurveType: types.Secp256k1,
+		},
+	})
+	if err != nil {
+		return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
 	}
 
-	addr := crypto.PubkeyToAddress(*pubkey)
+	addr := crypto.PubkeyToAddress(*child.key)
 	return &types.ConstructionDeriveResponse{
 		AccountIdentifier: &types.AccountIdentifier{
 			Address: addr.Hex(),
 		},
+		Metadata: metadata,
 	}, nil
 }
 
@@ -81,60 +141,121 @@ func (s *ConstructionAPIService) ConstructionPreprocess(
 	ctx context.Context,
 	request *types.ConstructionPreprocessRequest,
 ) (*types.ConstructionPreprocessResponse, *types.Error) {
-	descriptions := &parser.Descriptions{
-		OperationDescriptions: []*parser.OperationDescription{
-			{
-				Type: metadium.CallOpType,
-				Account: &parser.AccountDescription{
-					Exists: true,
-				},
-				Amount: &parser.AmountDescription{
-					Exists:   true,
-					Sign:     parser.NegativeAmountSign,
-					Currency: metadium.Currency,
-				},
-			},
-			{
-				Type: metadium.CallOpType,
-				Account: &parser.AccountDescription{
-					Exists: true,
-				},
-				Amount: &parser.AmountDescription{
-					Exists:   true,
-					Sign:     parser.PositiveAmountSign,
-					Currency: metadium.Currency,
-				},
-			},
-		},
-		ErrUnmatched: true,
+	if isBatch(request.Operations) {
+		return preprocessBatch(request)
 	}
 
-	matches, err := parser.MatchOperations(descriptions, request.Operations)
-	if err != nil {
-		return nil, wrapErr(ErrUnclearIntent, err)
+	intent, rErr := parseTransferIntent(request.Operations)
+	if rErr != nil {
+		return nil, rErr
 	}
 
-	fromOp, _ := matches[0].First()
-	fromAdd := fromOp.Account.Address
-	toOp, _ := matches[1].First()
-	toAdd := toOp.Account.Address
+	var input preprocessMetadata
+	if err := unmarshalJSONMap(request.Metadata, &input); err != nil {
+		return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
+	}
 
-	// Ensure valid from address
-	checkFrom, ok := metadium.ChecksumAddress(fromAdd)
-	if !ok {
-		return nil, wrapErr(ErrInvalidAddress, fmt.Errorf("%s is not a valid address", fromAdd))
+	preprocessOutput := &options{
+		From: intent.From,
 	}
 
-	// Ensure valid to address
-	_, ok = metadium.ChecksumAddress(toAdd)
-	if !ok {
-		return nil, wrapErr(ErrInvalidAddress, fmt.Errorf("%s is not a valid address", toAdd))
+	if len(input.FeePayer) > 0 {
+		feePayer, ok := metadium.ChecksumAddress(input.FeePayer)
+		if !ok {
+			return nil, wrapErr(ErrInvalidAddress, fmt.Errorf("%s is not a valid address", input.FeePayer))
+		}
+		preprocessOutput.FeePayer = feePayer
 	}
 
-	preprocessOutput := &options{
-		From: checkFrom,
+	// The call is passed through to /construction/metadata so
+	// its gas usage can be estimated. ERC-20 transfers are
+	// estimated against the token contract.
+	to, value, data := intent.txFields()
+	preprocessOutput.To = to
+	preprocessOutput.Data = data
+	if value.Sign() > 0 {
+		preprocessOutput.Value = value
+	}
+
+	call, rErr := parseContractCall(&input.contractCall, request.Operations)
+	if rErr != nil {
+		return nil, rErr
+	}
+	if call != nil {
+		if intent.isToken() || intent.Create {
+			return nil, wrapErr(ErrUnclearIntent, errors.New("contract calls can only transfer META"))
+		}
+
+		callData, err := call.calldata()
+		if err != nil {
+			return nil, wrapErr(ErrUnclearIntent, err)
+		}
+		preprocessOutput.Data = callData
+		preprocessOutput.MethodSignature = call.MethodSignature
+		preprocessOutput.MethodArgs = call.MethodArgs
+	}
+
+	if intent.Create {
+		creation := &input.contractCreation
+		if !creation.isSet() && request.Operations[0].Metadata != nil {
+			if err := unmarshalJSONMap(request.Operations[0].Metadata, creation); err != nil {
+				return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
+			}
+		}
+
+		initCode, err := creation.initCode()
+		if err != nil {
+			return nil, wrapErr(ErrUnclearIntent, err)
+		}
+		preprocessOutput.Data = initCode
 	}
 
+	if input.Cancel {
+		if call != nil || intent.isToken() || intent.Create || intent.From != intent.To || intent.Amount.Sign() != 0 {
+			return nil, wrapErr(
+				ErrUnclearIntent,
+				errors.New("a cancellation must be a zero-value META transfer to the sender"),
+			)
+		}
+	}
+
+	if input.Nonce != nil {
+		nonce := uint64(*input.Nonce)
+		preprocessOutput.Nonce = &nonce
+	}
+	preprocessOutput.Replace = input.Replace || input.Cancel
+	if preprocessOutput.Replace && preprocessOutput.Nonce == nil {
+		return nil, wrapErr(ErrUnclearIntent, errors.New("nonce is required to replace a transaction"))
+	}
+
+	if len(input.FeeTier) > 0 && !input.FeeTier.isValid() {
+		return nil, wrapErr(ErrInvalidFeeTier, fmt.Errorf("%s is not a valid fee tier", input.FeeTier))
+	}
+	preprocessOutput.FeeTier = input.FeeTier
+	preprocessOutput.RawTransaction = input.RawTransaction
+	if input.RawTransaction {
+		if rErr := checkRawTransaction(intent, preprocessOutput.MethodSignature); rErr != nil {
+			return nil, rErr
+		}
+	}
+
+	if !isSupportedSignatureType(input.SignatureType) {
+		return nil, wrapErr(
+			ErrUnsupportedSignatureType,
+			fmt.Errorf("%s is not a supported signature type", input.SignatureType),
+		)
+	}
+	preprocessOutput.SignatureType = input.SignatureType
+
+	if len(input.AccessList) > 0 && input.CreateAccessList {
+		return nil, wrapErr(
+			ErrUnclearIntent,
+			errors.New("an access list cannot be both provided and created"),
+		)
+	}
+	preprocessOutput.AccessList = input.AccessList
+	preprocessOutput.CreateAccessList = input.CreateAccessList
+
 	marshaled, err := marshalJSONMap(preprocessOutput)
 	if err != nil {
 		return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
@@ -159,18 +280,142 @@ func (s *ConstructionAPIService) ConstructionMetadata(
 		return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
 	}
 
-	nonce, err := s.client.PendingNonceAt(ctx, common.HexToAddress(input.From))
+	gasPrice, err := s.client.SuggestGasPrice(ctx)
 	if err != nil {
 		return nil, wrapErr(ErrGmet, err)
 	}
-	gasPrice, err := s.client.SuggestGasPrice(ctx)
+
+	var nonce uint64
+	if input.Nonce != nil {
+		nonce = *input.Nonce
+	} else {
+		nonce, err = s.client.PendingNonceAt(ctx, common.HexToAddress(input.From))
+		if err != nil {
+			return nil, wrapErr(ErrGmet, err)
+		}
+	}
+
+	head, err := s.client.HeaderByNumber(ctx, nil)
 	if err != nil {
 		return nil, wrapErr(ErrGmet, err)
 	}
 
+	// Access lists are only accepted by gmet once Berlin
+	// is active, like dynamic fees once London is active.
+	if (len(input.AccessList) > 0 || input.CreateAccessList) && !s.config.Params.IsBerlin(head.Number) {
+		return nil, wrapErr(
+			ErrUnclearIntent,
+			fmt.Errorf("access lists are not supported before Berlin, at block %s", head.Number),
+		)
+	}
+
+	// The access list is created before estimating the gas
+	// limit, as accessing its slots changes the gas used.
+	if input.CreateAccessList {
+		input.AccessList, err = s.client.CreateAccessList(ctx, ethereum.CallMsg{
+			From:  common.HexToAddress(input.From),
+			To:    recipient(input.To),
+			Value: input.Value,
+			Data:  input.Data,
+		})
+		if err != nil {
+			return nil, wrapErr(ErrGmet, err)
+		}
+	}
+
+	// A batch uses the largest gas limit of its transfers.
+	transfers := []*options{&input}
+	if len(input.Batch) > 0 {
+		transfers = input.Batch
+	}
+
+	var gasLimit uint64
+	for _, transfer := range transfers {
+		transferGasLimit, err := s.estimateGasLimit(ctx, transfer)
+		if err != nil {
+			return nil, wrapErr(ErrGmet, err)
+		}
+		if transferGasLimit > gasLimit {
+			gasLimit = transferGasLimit
+		}
+	}
+
 	metadata := &metadata{
-		Nonce:    nonce,
-		GasPrice: gasPrice,
+		Nonce:           nonce,
+		GasPrice:        gasPrice,
+		GasLimit:        gasLimit,
+		FeePayer:        input.FeePayer,
+		Data:            input.Data,
+		MethodSignature: input.MethodSignature,
+		MethodArgs:      input.MethodArgs,
+		RawTransaction:  input.RawTransaction,
+		SignatureType:   input.SignatureType,
+		AccessList:      input.AccessList,
+	}
+
+	// The fee of a tier is the gas price before London and
+	// the priority fee after. Without recent transactions,
+	// the fees suggested by gmet are used.
+	var tierFee *big.Int
+	if len(input.FeeTier) > 0 {
+		if !input.FeeTier.isValid() {
+			return nil, wrapErr(ErrInvalidFeeTier, fmt.Errorf("%s is not a valid fee tier", input.FeeTier))
+		}
+
+		tierFee, err = s.fees.suggest(ctx, input.FeeTier)
+		if err != nil {
+			return nil, wrapErr(ErrGmet, err)
+		}
+	}
+
+	if s.config.Params.IsLondon(head.Number) && head.BaseFee != nil {
+		gasTipCap := tierFee
+		if gasTipCap == nil {
+			gasTipCap, err = s.client.SuggestGasTipCap(ctx)
+			if err != nil {
+				return nil, wrapErr(ErrGmet, err)
+			}
+		}
+
+		// The max fee leaves room for the base fee to double
+		// before the transaction can no longer be included.
+		metadata.BaseFee = head.BaseFee
+		metadata.GasTipCap = gasTipCap
+		metadata.GasFeeCap = new(big.Int).Add(
+			gasTipCap,
+			new(big.Int).Mul(head.BaseFee, big.NewInt(2)), // nolint:gomnd
+		)
+	} else if tierFee != nil {
+		metadata.GasPrice = tierFee
+	}
+
+	if input.Replace {
+		if rErr := s.replaceFees(ctx, input.From, metadata); rErr != nil {
+			return nil, rErr
+		}
+	}
+
+	// The pending nonce is reserved once nothing else can fail, so
+	// concurrent constructions for the same sender get distinct
+	// nonces. Overridden nonces are used as is.
+	if input.Nonce == nil {
+		metadata.Nonce = s.nonces.reserve(common.HexToAddress(input.From), nonce, uint64(len(transfers)))
+	}
+	if len(input.To) == 0 && len(input.Data) > 0 {
+		metadata.ContractAddress = crypto.CreateAddress(common.HexToAddress(input.From), metadata.Nonce).Hex()
+	}
+
+	// Find suggested gas usage
+	gasPricePaid := metadata.GasPrice
+	if metadata.BaseFee != nil {
+		gasPricePaid = minBig(
+			new(big.Int).Add(metadata.BaseFee, metadata.GasTipCap),
+			metadata.GasFeeCap,
+		)
+	}
+	suggestedFee := new(big.Int).Mul(gasPricePaid, new(big.Int).SetUint64(gasLimit))
+	if len(input.Batch) > 0 {
+		suggestedFee.Mul(suggestedFee, big.NewInt(int64(len(input.Batch))))
 	}
 
 	metadataMap, err := marshalJSONMap(metadata)
@@ -178,55 +423,89 @@ func (s *ConstructionAPIService) ConstructionMetadata(
 		return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
 	}
 
-	// Find suggested gas usage
-	suggestedFee := metadata.GasPrice.Int64() * metadium.TransferGasLimit
-
 	return &types.ConstructionMetadataResponse{
 		Metadata: metadataMap,
 		SuggestedFee: []*types.Amount{
 			{
-				Value:    strconv.FormatInt(suggestedFee, 10),
+				Value:    suggestedFee.String(),
 				Currency: metadium.Currency,
 			},
 		},
 	}, nil
 }
 
+// estimateGasLimit estimates the gas used by the transaction described
+// by input. Plain transfers always use exactly TransferGasLimit, so the
+// configured margin is only added when the transaction executes code.
+// The access list of input adds to the gas used.
+func (s *ConstructionAPIService) estimateGasLimit(
+	ctx context.Context,
+	input *options,
+) (uint64, error) {
+	// Contract deployments have no recipient.
+	to := recipient(input.To)
+
+	if to == nil && len(input.Data) == 0 && len(input.AccessList) == 0 {
+		return uint64(metadium.TransferGasLimit), nil
+	}
+
+	gasLimit, err := s.client.EstimateGas(ctx, ethereum.CallMsg{
+		From:       common.HexToAddress(input.From),
+		To:         to,
+		Value:      input.Value,
+		Data:       input.Data,
+		AccessList: input.AccessList,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if gasLimit > uint64(metadium.TransferGasLimit) {
+		gasLimit += gasLimit * s.config.GasLimitMargin / 100 // nolint:gomnd
+	}
+
+	return gasLimit, nil
+}
+
+// replaceFees raises the fees in metadata to replace the pending
+// transaction of from with the same nonce. gmet rejects replacements
+// that do not raise the fees by at least TxPoolPriceBump percent.
+func (s *ConstructionAPIService) replaceFees(
+	ctx context.Context,
+	from string,
+	metadata *metadata,
+) *types.Error {
+	pendingTx, _, err := s.client.PendingTransaction(ctx, common.HexToAddress(from), metadata.Nonce)
+	if err != nil {
+		if errors.Is(err, metadium.ErrTransactionNotFound) {
+			return wrapErr(ErrTransactionNotFound, err)
+		}
+
+		return wrapErr(ErrGmet, err)
+	}
+
+	// The fee cap of a legacy transaction is its gas price.
+	metadata.GasPrice = maxBig(metadata.GasPrice, bumpGasPrice(pendingTx.GasFeeCap()))
+	if metadata.GasTipCap != nil {
+		metadata.GasTipCap = maxBig(metadata.GasTipCap, bumpGasPrice(pendingTx.GasTipCap()))
+		metadata.GasFeeCap = maxBig(metadata.GasFeeCap, bumpGasPrice(pendingTx.GasFeeCap()))
+	}
+
+	return nil
+}
+
 // ConstructionPayloads implements the /construction/payloads endpoint.
 func (s *ConstructionAPIService) ConstructionPayloads(
 	ctx context.Context,
 	request *types.ConstructionPayloadsRequest,
 ) (*types.ConstructionPayloadsResponse, *types.Error) {
-	descriptions := &parser.Descriptions{
-		OperationDescriptions: []*parser.OperationDescription{
-			{
-				Type: metadium.CallOpType,
-				Account: &parser.AccountDescription{
-					Exists: true,
-				},
-				Amount: &parser.AmountDescription{
-					Exists:   true,
-					Sign:     parser.NegativeAmountSign,
-					Currency: metadium.Currency,
-				},
-			},
-			{
-				Type: metadium.CallOpType,
-				Account: &parser.AccountDescription{
-					Exists: true,
-				},
-				Amount: &parser.AmountDescription{
-					Exists:   true,
-					Sign:     parser.PositiveAmountSign,
-					Currency: metadium.Currency,
-				},
-			},
-		},
-		ErrUnmatched: true,
+	if isBatch(request.Operations) {
+		return s.payloadsBatch(request)
 	}
-	matches, err := parser.MatchOperations(descriptions, request.Operations)
-	if err != nil {
-		return nil, wrapErr(ErrUnclearIntent, err)
+
+	intent, rErr := parseTransferIntent(request.Operations)
+	if rErr != nil {
+		return nil, rErr
 	}
 
 	// Convert map to Metadata struct
@@ -235,67 +514,146 @@ func (s *ConstructionAPIService) ConstructionPayloads(
 		return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
 	}
 
+	unsignedTx, rErr := s.newUnsignedTransaction(intent, &metadata, metadata.Nonce)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	payloads, rErr := signingPayloads(unsignedTx)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	unsignedTxJSON, err := json.Marshal(unsignedTx)
+	if err != nil {
+		return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
+	}
+
+	return &types.ConstructionPayloadsResponse{
+		UnsignedTransaction: string(unsignedTxJSON),
+		Payloads:            payloads,
+	}, nil
+}
+
+// newUnsignedTransaction builds the unsigned transaction of
+// intent with the provided nonce.
+func (s *ConstructionAPIService) newUnsignedTransaction(
+	intent *transferIntent,
+	metadata *metadata,
+	nonce uint64,
+) (*transaction, *types.Error) {
 	// Required Fields for constructing a real Metadium transaction
-	toOp, amount := matches[1].First()
-	toAdd := toOp.Account.Address
-	nonce := metadata.Nonce
+	to, value, data := intent.txFields()
+	if len(metadata.Data) > 0 {
+		// The calldata of ERC-20 transfers is derived from
+		// the intent, so it must match the estimated call.
+		if intent.isToken() && !bytes.Equal(metadata.Data, data) {
+			return nil, wrapErr(ErrUnclearIntent, errors.New("data does not match the token transfer"))
+		}
+		data = metadata.Data
+	}
+	if intent.Create && len(data) == 0 {
+		return nil, wrapErr(ErrUnclearIntent, fmt.Errorf("%s is required to deploy a contract", metadium.BytecodeKey))
+	}
+	if metadata.RawTransaction {
+		if rErr := checkRawTransaction(intent, metadata.MethodSignature); rErr != nil {
+			return nil, rErr
+		}
+	}
 	gasPrice := metadata.GasPrice
 	chainID := s.config.Params.ChainID
-	transferGasLimit := uint64(metadium.TransferGasLimit)
-	transferData := []byte{}
+	gasLimit := metadata.GasLimit
+	if gasLimit == 0 {
+		gasLimit = uint64(metadium.TransferGasLimit)
+	}
 
-	// Additional Fields for constructing custom Metadium tx struct
-	fromOp, _ := matches[0].First()
-	fromAdd := fromOp.Account.Address
+	unsignedTx := &transaction{
+		From:      intent.From,
+		To:        to,
+		Value:     value,
+		Data:      data,
+		Nonce:     nonce,
+		GasPrice:  gasPrice,
+		GasLimit:  gasLimit,
+		ChainID:   chainID,
+		GasFeeCap: metadata.GasFeeCap,
+		GasTipCap: metadata.GasTipCap,
 
-	// Ensure valid from address
-	checkFrom, ok := metadium.ChecksumAddress(fromAdd)
-	if !ok {
-		return nil, wrapErr(ErrInvalidAddress, fmt.Errorf("%s is not a valid address", fromAdd))
-	}
+		MethodSignature: metadata.MethodSignature,
+		MethodArgs:      metadata.MethodArgs,
 
-	// Ensure valid to address
-	checkTo, ok := metadium.ChecksumAddress(toAdd)
-	if !ok {
-		return nil, wrapErr(ErrInvalidAddress, fmt.Errorf("%s is not a valid address", toAdd))
-	}
+		RawTransaction: metadata.RawTransaction,
+		SignatureType:  metadata.SignatureType,
 
-	tx := ethTypes.NewTransaction(
-		nonce,
-		common.HexToAddress(checkTo),
-		amount,
-		transferGasLimit,
-		gasPrice,
-		transferData,
-	)
+		AccessList: metadata.AccessList,
+	}
+	if len(metadata.FeePayer) > 0 {
+		feePayer, ok := metadium.ChecksumAddress(metadata.FeePayer)
+		if !ok {
+			return nil, wrapErr(ErrInvalidAddress, fmt.Errorf("%s is not a valid address", metadata.FeePayer))
+		}
 
-	unsignedTx := &transaction{
-		From:     checkFrom,
-		To:       checkTo,
-		Value:    amount,
-		Data:     tx.Data(),
-		Nonce:    tx.Nonce(),
-		GasPrice: gasPrice,
-		GasLimit: tx.Gas(),
-		ChainID:  chainID,
+		// Fee-delegated transactions wrap a dynamic fee
+		// transaction, even before London is active.
+		if !unsignedTx.isDynamicFee() {
+			unsignedTx.GasFeeCap = gasPrice
+			unsignedTx.GasTipCap = gasPrice
+		}
+		unsignedTx.FeePayer = feePayer
+		unsignedTx.SenderSignature = metadata.SenderSignature
+	}
+	if unsignedTx.isDynamicFee() {
+		unsignedTx.GasPrice = unsignedTx.GasFeeCap
+	}
+	if intent.isToken() {
+		unsignedTx.Currency = intent.Currency
+	}
+	if intent.Create {
+		unsignedTx.ContractAddress = crypto.CreateAddress(common.HexToAddress(intent.From), nonce).Hex()
 	}
 
-	// Construct SigningPayload
-	signer := ethTypes.NewEIP155Signer(chainID)
-	payload := &types.SigningPayload{
-		AccountIdentifier: &types.AccountIdentifier{Address: checkFrom},
-		Bytes:             signer.Hash(tx).Bytes(),
-		SignatureType:     types.EcdsaRecovery,
+	if rErr := s.checkCaps(newEthTransaction(unsignedTx)); rErr != nil {
+		return nil, rErr
 	}
 
-	unsignedTxJSON, err := json.Marshal(unsignedTx)
-	if err != nil {
-		return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
+	return unsignedTx, nil
+}
+
+// signingPayloads returns the payloads to sign for unsignedTx.
+//
+// Fee-delegated transactions are signed in two rounds, as the fee
+// payer signs over the sender signature. Until the sender has signed,
+// only the payload of the sender is returned. Once SenderSignature is
+// populated, only the payload of the fee payer is returned.
+func signingPayloads(unsignedTx *transaction) ([]*types.SigningPayload, *types.Error) {
+	if unsignedTx.isFeeDelegated() && len(unsignedTx.SenderSignature) > 0 {
+		feeDelegatedTx, rErr := senderSignedTransaction(unsignedTx)
+		if rErr != nil {
+			return nil, rErr
+		}
+
+		feePayerHash, err := feeDelegatedTx.FeePayerHash()
+		if err != nil {
+			return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
+		}
+
+		return []*types.SigningPayload{
+			{
+				AccountIdentifier: &types.AccountIdentifier{Address: unsignedTx.FeePayer},
+				Bytes:             feePayerHash.Bytes(),
+				SignatureType:     unsignedTx.signatureType(),
+			},
+		}, nil
 	}
 
-	return &types.ConstructionPayloadsResponse{
-		UnsignedTransaction: string(unsignedTxJSON),
-		Payloads:            []*types.SigningPayload{payload},
+	tx := newEthTransaction(unsignedTx)
+	signer := ethTypes.NewLondonSigner(unsignedTx.ChainID)
+	return []*types.SigningPayload{
+		{
+			AccountIdentifier: &types.AccountIdentifier{Address: unsignedTx.From},
+			Bytes:             signer.Hash(tx).Bytes(),
+			SignatureType:     unsignedTx.signatureType(),
+		},
 	}, nil
 }
 
@@ -305,34 +663,78 @@ func (s *ConstructionAPIService) ConstructionCombine(
 	ctx context.Context,
 	request *types.ConstructionCombineRequest,
 ) (*types.ConstructionCombineResponse, *types.Error) {
+	if isBatchTransaction(request.UnsignedTransaction) {
+		return s.combineBatch(request)
+	}
+
 	var unsignedTx transaction
 	if err := json.Unmarshal([]byte(request.UnsignedTransaction), &unsignedTx); err != nil {
 		return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
 	}
 
-	ethTransaction := ethTypes.NewTransaction(
-		unsignedTx.Nonce,
-		common.HexToAddress(unsignedTx.To),
-		unsignedTx.Value,
-		unsignedTx.GasLimit,
-		unsignedTx.GasPrice,
-		unsignedTx.Data,
-	)
+	if rErr := s.checkChainID(unsignedTx.ChainID); rErr != nil {
+		return nil, rErr
+	}
+
+	if rErr := s.checkCaps(newEthTransaction(&unsignedTx)); rErr != nil {
+		return nil, rErr
+	}
+
+	signedTxJSON, rErr := combineTransaction(&unsignedTx, request.Signatures)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	return &types.ConstructionCombineResponse{
+		SignedTransaction: string(signedTxJSON),
+	}, nil
+}
+
+// combineTransaction attaches signatures to unsignedTx and
+// returns the JSON of the signed transaction.
+func combineTransaction(
+	unsignedTx *transaction,
+	signatures []*types.Signature,
+) ([]byte, *types.Error) {
+	if unsignedTx.isFeeDelegated() {
+		return combineFeeDelegated(unsignedTx, signatures)
+	}
 
-	signer := ethTypes.NewEIP155Signer(unsignedTx.ChainID)
-	signedTx, err := ethTransaction.WithSignature(signer, request.Signatures[0].Bytes)
+	if len(signatures) != 1 {
+		return nil, wrapErr(
+			ErrSignatureInvalid,
+			fmt.Errorf("expected 1 signature, got %d signatures", len(signatures)),
+		)
+	}
+
+	ethTransaction := newEthTransaction(unsignedTx)
+
+	signer := ethTypes.NewLondonSigner(unsignedTx.ChainID)
+	signature, err := recoverableSignature(signatures[0], signer.Hash(ethTransaction), unsignedTx.From)
 	if err != nil {
 		return nil, wrapErr(ErrSignatureInvalid, err)
 	}
 
-	signedTxJSON, err := signedTx.MarshalJSON()
+	signedTx, err := ethTransaction.WithSignature(signer, signature)
+	if err != nil {
+		return nil, wrapErr(ErrSignatureInvalid, err)
+	}
+
+	sender, err := ethTypes.Sender(signer, signedTx)
+	if err != nil {
+		return nil, wrapErr(ErrSignatureInvalid, err)
+	}
+
+	if err := verifySigner(signatures[0], sender, unsignedTx.From); err != nil {
+		return nil, wrapErr(ErrSignatureInvalid, err)
+	}
+
+	signedTxJSON, err := encodeSignedTransaction(signedTx, unsignedTx)
 	if err != nil {
 		return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
 	}
 
-	return &types.ConstructionCombineResponse{
-		SignedTransaction: string(signedTxJSON),
-	}, nil
+	return signedTxJSON, nil
 }
 
 // ConstructionHash implements the /construction/hash endpoint.
@@ -340,69 +742,337 @@ func (s *ConstructionAPIService) ConstructionHash(
 	ctx context.Context,
 	request *types.ConstructionHashRequest,
 ) (*types.TransactionIdentifierResponse, *types.Error) {
-	signedTx := ethTypes.Transaction{}
-	if err := signedTx.UnmarshalJSON([]byte(request.SignedTransaction)); err != nil {
-		return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
+	if isBatchTransaction(request.SignedTransaction) {
+		return hashBatch(request)
 	}
 
-	hash := signedTx.Hash().Hex()
+	hash, err := signedTransactionHash([]byte(request.SignedTransaction))
+	if err != nil {
+		return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
+	}
 
 	return &types.TransactionIdentifierResponse{
 		TransactionIdentifier: &types.TransactionIdentifier{
-			Hash: hash,
+			Hash: hash.Hex(),
 		},
 	}, nil
 }
 
-// ConstructionParse implements the /construction/parse endpoint.
-func (s *ConstructionAPIService) ConstructionParse(
+// signedTransactionHash returns the hash of a signed transaction.
+func signedTransactionHash(data []byte) (common.Hash, error) {
+	signedTx, feeDelegatedTx, err := unmarshalSignedTransaction(data)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	if feeDelegatedTx != nil {
+		return feeDelegatedTx.Hash(), nil
+	}
+
+	return signedTx.Hash(), nil
+}
+
+// ConstructionParse implements the /construction/parse endpoint.
+func (s *ConstructionAPIService) ConstructionParse(
 	ctx context.Context,
 	request *types.ConstructionParseRequest,
 ) (*types.ConstructionParseResponse, *types.Error) {
+	if isBatchTransaction(request.Transaction) {
+		return s.parseBatch(request)
+	}
+
+	ops, metadata, signers, rErr := s.parseTransaction([]byte(request.Transaction), request.Signed)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	metaMap, err := marshalJSONMap(metadata)
+	if err != nil {
+		return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
+	}
+
+	return &types.ConstructionParseResponse{
+		Operations:               ops,
+		AccountIdentifierSigners: signers,
+		Metadata:                 metaMap,
+	}, nil
+}
+
+// parseTransaction returns the operations, metadata and signers of
+// a signed or unsigned transaction. Unsigned transactions have no
+// signers.
+func (s *ConstructionAPIService) parseTransaction(
+	data []byte,
+	signed bool,
+) ([]*types.Operation, *parseMetadata, []*types.AccountIdentifier, *types.Error) {
 	var tx transaction
-	if !request.Signed {
-		err := json.Unmarshal([]byte(request.Transaction), &tx)
+	if !signed {
+		err := json.Unmarshal(data, &tx)
 		if err != nil {
-			return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
+			return nil, nil, nil, wrapErr(ErrUnableToParseIntermediateResult, err)
 		}
 	} else {
-		t := new(ethTypes.Transaction)
-		err := t.UnmarshalJSON([]byte(request.Transaction))
+		t, feeDelegatedTx, err := unmarshalSignedTransaction(data)
 		if err != nil {
-			return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
+			return nil, nil, nil, wrapErr(ErrUnableToParseIntermediateResult, err)
 		}
 
-		tx.To = t.To().String()
+		if t.To() != nil {
+			tx.To = t.To().String()
+		}
 		tx.Value = t.Value()
 		tx.Data = t.Data()
 		tx.Nonce = t.Nonce()
 		tx.GasPrice = t.GasPrice()
 		tx.GasLimit = t.Gas()
 		tx.ChainID = t.ChainId()
+		if t.Type() == ethTypes.DynamicFeeTxType {
+			tx.GasFeeCap = t.GasFeeCap()
+			tx.GasTipCap = t.GasTipCap()
+		}
+		tx.AccessList = t.AccessList()
 
-		msg, err := t.AsMessage(ethTypes.NewEIP155Signer(t.ChainId()), nil)
+		msg, err := t.AsMessage(ethTypes.NewLondonSigner(t.ChainId()), nil)
 		if err != nil {
-			return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
+			return nil, nil, nil, wrapErr(ErrUnableToParseIntermediateResult, err)
 		}
 
 		tx.From = msg.From().Hex()
+
+		if feeDelegatedTx != nil {
+			feePayer, err := feeDelegatedTx.FeePayerSender()
+			if err != nil {
+				return nil, nil, nil, wrapErr(ErrUnableToParseIntermediateResult, err)
+			}
+			tx.FeePayer = feePayer.Hex()
+		}
+
+		// Raw transactions carry no currency or method, so they
+		// are parsed as META transfers with their data. Raw ERC-20
+		// transfers and contract calls with a method signature are
+		// rejected by checkRawTransaction when they are constructed.
+		if !isRawTransaction(data) {
+			var extra signedTransactionExtra
+			if err := json.Unmarshal(data, &extra); err != nil {
+				return nil, nil, nil, wrapErr(ErrUnableToParseIntermediateResult, err)
+			}
+			tx.Currency = extra.Currency
+			tx.MethodSignature = extra.MethodSignature
+			tx.MethodArgs = extra.MethodArgs
+		}
+	}
+
+	if rErr := s.checkChainID(tx.ChainID); rErr != nil {
+		return nil, nil, nil, rErr
 	}
 
 	// Ensure valid from address
 	checkFrom, ok := metadium.ChecksumAddress(tx.From)
 	if !ok {
-		return nil, wrapErr(ErrInvalidAddress, fmt.Errorf("%s is not a valid address", tx.From))
+		return nil, nil, nil, wrapErr(ErrInvalidAddress, fmt.Errorf("%s is not a valid address", tx.From))
 	}
 
+	metadata := &parseMetadata{
+		Nonce:     tx.Nonce,
+		GasPrice:  tx.GasPrice,
+		ChainID:   tx.ChainID,
+		GasFeeCap: tx.GasFeeCap,
+		GasTipCap: tx.GasTipCap,
+		FeePayer:  tx.FeePayer,
+	}
+	if tx.hasAccessList() {
+		metadata.AccessList = tx.AccessList
+	}
+
+	var ops []*types.Operation
+	if len(tx.To) == 0 {
+		// Contract deployments have no recipient. The address
+		// of the contract is derived from the sender and nonce.
+		ops = createOps(checkFrom, tx.Value)
+		metadata.ContractAddress = crypto.CreateAddress(common.HexToAddress(checkFrom), tx.Nonce).Hex()
+		metadata.Data = tx.Data
+	} else {
+		var rErr *types.Error
+		ops, rErr = transferOps(&tx, checkFrom, metadata)
+		if rErr != nil {
+			return nil, nil, nil, rErr
+		}
+	}
+
+	signers := []*types.AccountIdentifier{}
+	if signed {
+		signers = append(signers, &types.AccountIdentifier{
+			Address: checkFrom,
+		})
+		if tx.isFeeDelegated() {
+			signers = append(signers, &types.AccountIdentifier{
+				Address: tx.FeePayer,
+			})
+		}
+	}
+
+	return ops, metadata, signers, nil
+}
+
+// ConstructionSubmit implements the /construction/submit endpoint.
+func (s *ConstructionAPIService) ConstructionSubmit(
+	ctx context.Context,
+	request *types.ConstructionSubmitRequest,
+) (*types.TransactionIdentifierResponse, *types.Error) {
+	if s.config.Mode != configuration.Online {
+		return nil, ErrUnavailableOffline
+	}
+
+	if isBatchTransaction(request.SignedTransaction) {
+		return s.submitBatch(ctx, request)
+	}
+
+	hash, rErr := s.submitTransaction(ctx, []byte(request.SignedTransaction))
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	txIdentifier := &types.TransactionIdentifier{
+		Hash: hash.Hex(),
+	}
+	return &types.TransactionIdentifierResponse{
+		TransactionIdentifier: txIdentifier,
+	}, nil
+}
+
+// submitTransaction broadcasts a signed transaction and
+// returns its hash.
+func (s *ConstructionAPIService) submitTransaction(
+	ctx context.Context,
+	data []byte,
+) (common.Hash, *types.Error) {
+	signedTx, feeDelegatedTx, err := unmarshalSignedTransaction(data)
+	if err != nil {
+		return common.Hash{}, wrapErr(ErrUnableToParseIntermediateResult, err)
+	}
+
+	if rErr := s.checkChainID(signedTx.ChainId()); rErr != nil {
+		return common.Hash{}, rErr
+	}
+
+	// The caps are checked again in case the transaction
+	// was not constructed by this implementation.
+	if rErr := s.checkCaps(signedTx); rErr != nil {
+		return common.Hash{}, rErr
+	}
+
+	if feeDelegatedTx != nil {
+		rawTx, err := feeDelegatedTx.MarshalBinary()
+		if err != nil {
+			return common.Hash{}, wrapErr(ErrUnableToParseIntermediateResult, err)
+		}
+
+		if err := s.client.SendRawTransaction(ctx, rawTx); err != nil && !isAlreadyKnown(err) {
+			s.releaseNonce(signedTx)
+			return common.Hash{}, broadcastErr(err)
+		}
+
+		s.track(feeDelegatedTx.Hash(), signedTx, rawTx)
+		return feeDelegatedTx.Hash(), nil
+	}
+
+	// A transaction already in the txpool was broadcast
+	// before, so its hash is returned as if it was not.
+	if err := s.client.SendTransaction(ctx, signedTx); err != nil && !isAlreadyKnown(err) {
+		s.releaseNonce(signedTx)
+		return common.Hash{}, broadcastErr(err)
+	}
+
+	s.track(signedTx.Hash(), signedTx, nil)
+	return signedTx.Hash(), nil
+}
+
+// track records a broadcast transaction in the tracker, so that
+// its status is returned by the transaction_status /call method.
+// For fee-delegated transactions, signedTx is the sender
+// transaction and rawTx is the fee-delegated transaction.
+func (s *ConstructionAPIService) track(hash common.Hash, signedTx *ethTypes.Transaction, rawTx []byte) {
+	from, err := ethTypes.Sender(ethTypes.NewLondonSigner(signedTx.ChainId()), signedTx)
+	if err != nil {
+		return
+	}
+
+	s.tracker.record(&trackedTransaction{
+		hash:     hash,
+		from:     from,
+		nonce:    signedTx.Nonce(),
+		signedTx: signedTx,
+		rawTx:    rawTx,
+	})
+}
+
+// releaseNonce releases the reservation of the nonce of
+// signedTx, whose broadcast failed, so that it is handed
+// out again by /construction/metadata.
+func (s *ConstructionAPIService) releaseNonce(signedTx *ethTypes.Transaction) {
+	from, err := ethTypes.Sender(ethTypes.NewLondonSigner(signedTx.ChainId()), signedTx)
+	if err != nil {
+		return
+	}
+
+	s.nonces.release(from, signedTx.Nonce())
+}
+
+// transferOps returns the operations of tx, a transfer of META, of an
+// ERC-20 token or a contract call, and adds the contract call to metadata.
+func transferOps(
+	tx *transaction,
+	checkFrom string,
+	metadata *parseMetadata,
+) ([]*types.Operation, *types.Error) {
 	// Ensure valid to address
 	checkTo, ok := metadium.ChecksumAddress(tx.To)
 	if !ok {
 		return nil, wrapErr(ErrInvalidAddress, fmt.Errorf("%s is not a valid address", tx.To))
 	}
 
-	ops := []*types.Operation{
+	opType := metadium.CallOpType
+	currency := metadium.Currency
+	value := tx.Value
+	if tx.Currency != nil && !metadium.IsNativeCurrency(tx.Currency) {
+		tokenAddress, ok := metadium.TokenContractAddress(tx.Currency)
+		if !ok || tokenAddress != checkTo {
+			return nil, wrapErr(
+				ErrUnableToParseIntermediateResult,
+				fmt.Errorf("%s is not the contract of %s", checkTo, tx.Currency.Symbol),
+			)
+		}
+
+		recipient, amount, ok := metadium.ParseERC20TransferData(tx.Data)
+		if !ok {
+			return nil, wrapErr(
+				ErrUnableToParseIntermediateResult,
+				fmt.Errorf("%s is not an ERC-20 transfer", hexutil.Encode(tx.Data)),
+			)
+		}
+
+		opType = metadium.ERC20TransferOpType
+		currency = tx.Currency
+		checkTo = recipient.Hex()
+		value = amount
+	}
+
+	if metadium.IsNativeCurrency(currency) && len(tx.Data) > 0 {
+		if len(tx.MethodSignature) > 0 {
+			args, err := metadium.ParseContractCallData(tx.MethodSignature, tx.Data)
+			if err != nil {
+				return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
+			}
+			metadata.MethodSignature = tx.MethodSignature
+			metadata.MethodArgs = args
+		} else {
+			metadata.Data = tx.Data
+		}
+	}
+
+	return []*types.Operation{
 		{
-			Type: metadium.CallOpType,
+			Type: opType,
 			OperationIdentifier: &types.OperationIdentifier{
 				Index: 0,
 			},
@@ -410,12 +1080,12 @@ func (s *ConstructionAPIService) ConstructionParse(
 				Address: checkFrom,
 			},
 			Amount: &types.Amount{
-				Value:    new(big.Int).Neg(tx.Value).String(),
-				Currency: metadium.Currency,
+				Value:    new(big.Int).Neg(value).String(),
+				Currency: currency,
 			},
 		},
 		{
-			Type: metadium.CallOpType,
+			Type: opType,
 			OperationIdentifier: &types.OperationIdentifier{
 				Index: 1,
 			},
@@ -428,65 +1098,745 @@ func (s *ConstructionAPIService) ConstructionParse(
 				Address: checkTo,
 			},
 			Amount: &types.Amount{
-				Value:    tx.Value.String(),
+				Value:    value.String(),
+				Currency: currency,
+			},
+		},
+	}, nil
+}
+
+// createOps returns the operation of a contract
+// deployment from checkFrom endowed with value.
+func createOps(checkFrom string, value *big.Int) []*types.Operation {
+	return []*types.Operation{
+		{
+			Type: metadium.CreateOpType,
+			OperationIdentifier: &types.OperationIdentifier{
+				Index: 0,
+			},
+			Account: &types.AccountIdentifier{
+				Address: checkFrom,
+			},
+			Amount: &types.Amount{
+				Value:    new(big.Int).Neg(value).String(),
 				Currency: metadium.Currency,
 			},
 		},
 	}
+}
 
-	metadata := &parseMetadata{
-		Nonce:    tx.Nonce,
-		GasPrice: tx.GasPrice,
-		ChainID:  tx.ChainID,
+// transferDescriptions matches a transfer of META between
+// two accounts. Contract calls may transfer no value.
+var transferDescriptions = &parser.Descriptions{
+	OperationDescriptions: []*parser.OperationDescription{
+		{
+			Type: metadium.CallOpType,
+			Account: &parser.AccountDescription{
+				Exists: true,
+			},
+			Amount: &parser.AmountDescription{
+				Exists: true,
+				Sign:   parser.NegativeOrZeroAmountSign,
+			},
+		},
+		{
+			Type: metadium.CallOpType,
+			Account: &parser.AccountDescription{
+				Exists: true,
+			},
+			Amount: &parser.AmountDescription{
+				Exists: true,
+				Sign:   parser.PositiveOrZeroAmountSign,
+			},
+		},
+	},
+	OppositeOrZeroAmounts: [][]int{{0, 1}},
+	ErrUnmatched:          true,
+}
+
+// tokenTransferDescriptions matches a transfer of an
+// ERC-20 token between two accounts.
+var tokenTransferDescriptions = &parser.Descriptions{
+	OperationDescriptions: []*parser.OperationDescription{
+		{
+			Type: metadium.ERC20TransferOpType,
+			Account: &parser.AccountDescription{
+				Exists: true,
+			},
+			Amount: &parser.AmountDescription{
+				Exists: true,
+				Sign:   parser.NegativeAmountSign,
+			},
+		},
+		{
+			Type: metadium.ERC20TransferOpType,
+			Account: &parser.AccountDescription{
+				Exists: true,
+			},
+			Amount: &parser.AmountDescription{
+				Exists: true,
+				Sign:   parser.PositiveAmountSign,
+			},
+		},
+	},
+	OppositeAmounts: [][]int{{0, 1}},
+	ErrUnmatched:    true,
+}
+
+// createDescriptions matches the deployment of a contract,
+// optionally endowed with META.
+var createDescriptions = &parser.Descriptions{
+	OperationDescriptions: []*parser.OperationDescription{
+		{
+			Type: metadium.CreateOpType,
+			Account: &parser.AccountDescription{
+				Exists: true,
+			},
+			Amount: &parser.AmountDescription{
+				Exists:   true,
+				Sign:     parser.NegativeOrZeroAmountSign,
+				Currency: metadium.Currency,
+			},
+		},
+	},
+	ErrUnmatched: true,
+}
+
+// transferIntent is a validated transfer parsed from
+// the operations of a construction request.
+type transferIntent struct {
+	From     string
+	To       string
+	Amount   *big.Int
+	Currency *types.Currency
+
+	// TokenAddress is the ERC-20 contract address. It is
+	// empty when the intent transfers META.
+	TokenAddress string
+
+	// Create is set when the intent deploys a contract,
+	// in which case To is empty.
+	Create bool
+}
+
+func parseTransferIntent(operations []*types.Operation) (*transferIntent, *types.Error) {
+	if len(operations) == 1 && operations[0].Type == metadium.CreateOpType {
+		return parseCreateIntent(operations)
 	}
-	metaMap, err := marshalJSONMap(metadata)
+
+	// ERC-20 transfers use the operation type of the
+	// token movements /block returns for them.
+	descriptions := transferDescriptions
+	if len(operations) > 0 && operations[0].Type == metadium.ERC20TransferOpType {
+		descriptions = tokenTransferDescriptions
+	}
+
+	matches, err := parser.MatchOperations(descriptions, operations)
+	if err != nil {
+		return nil, wrapErr(ErrUnclearIntent, err)
+	}
+
+	fromOp, _ := matches[0].First()
+	fromAdd := fromOp.Account.Address
+	toOp, amount := matches[1].First()
+	toAdd := toOp.Account.Address
+
+	// Ensure valid from address
+	checkFrom, ok := metadium.ChecksumAddress(fromAdd)
+	if !ok {
+		return nil, wrapErr(ErrInvalidAddress, fmt.Errorf("%s is not a valid address", fromAdd))
+	}
+
+	// Ensure valid to address
+	checkTo, ok := metadium.ChecksumAddress(toAdd)
+	if !ok {
+		return nil, wrapErr(ErrInvalidAddress, fmt.Errorf("%s is not a valid address", toAdd))
+	}
+
+	currency := toOp.Amount.Currency
+	if types.Hash(fromOp.Amount.Currency) != types.Hash(currency) {
+		return nil, wrapErr(ErrUnclearIntent, errors.New("operation currencies do not match"))
+	}
+
+	intent := &transferIntent{
+		From:     checkFrom,
+		To:       checkTo,
+		Amount:   amount,
+		Currency: currency,
+	}
+	if metadium.IsNativeCurrency(currency) {
+		if fromOp.Type != metadium.CallOpType {
+			return nil, wrapErr(
+				ErrUnclearIntent,
+				fmt.Errorf("META transfers must be %s operations", metadium.CallOpType),
+			)
+		}
+
+		return intent, nil
+	}
+
+	if fromOp.Type != metadium.ERC20TransferOpType {
+		return nil, wrapErr(
+			ErrUnclearIntent,
+			fmt.Errorf("ERC-20 transfers must be %s operations", metadium.ERC20TransferOpType),
+		)
+	}
+
+	tokenAddress, ok := metadium.TokenContractAddress(currency)
+	if !ok {
+		return nil, wrapErr(
+			ErrUnclearIntent,
+			fmt.Errorf("%s has no valid %s", currency.Symbol, metadium.ContractAddressKey),
+		)
+	}
+	intent.TokenAddress = tokenAddress
+
+	return intent, nil
+}
+
+// parseCreateIntent parses the intent of a contract deployment,
+// endowing the contract with the META debited from the deployer.
+func parseCreateIntent(operations []*types.Operation) (*transferIntent, *types.Error) {
+	matches, err := parser.MatchOperations(createDescriptions, operations)
+	if err != nil {
+		return nil, wrapErr(ErrUnclearIntent, err)
+	}
+
+	fromOp, amount := matches[0].First()
+	checkFrom, ok := metadium.ChecksumAddress(fromOp.Account.Address)
+	if !ok {
+		return nil, wrapErr(ErrInvalidAddress, fmt.Errorf("%s is not a valid address", fromOp.Account.Address))
+	}
+
+	return &transferIntent{
+		From:     checkFrom,
+		Amount:   new(big.Int).Neg(amount),
+		Currency: metadium.Currency,
+		Create:   true,
+	}, nil
+}
+
+func (i *transferIntent) isToken() bool {
+	return len(i.TokenAddress) > 0
+}
+
+// txFields returns the recipient, value and data of the
+// transaction that executes the intent. ERC-20 transfers
+// are sent to the token contract with zero value and
+// contract deployments have no recipient.
+func (i *transferIntent) txFields() (string, *big.Int, []byte) {
+	if i.Create {
+		return "", i.Amount, []byte{}
+	}
+
+	if !i.isToken() {
+		return i.To, i.Amount, []byte{}
+	}
+
+	data := metadium.ERC20TransferData(common.HexToAddress(i.To), i.Amount)
+	return i.TokenAddress, big.NewInt(0), data
+}
+
+// checkChainID returns an error if chainID is
+// not the chain ID of the network.
+func (s *ConstructionAPIService) checkChainID(chainID *big.Int) *types.Error {
+	if chainID == nil || chainID.Cmp(s.config.Params.ChainID) != 0 {
+		return wrapErr(
+			ErrInvalidChainID,
+			fmt.Errorf("chain ID %v is not the chain ID %s of the network", chainID, s.config.Params.ChainID),
+		)
+	}
+
+	return nil
+}
+
+// checkCaps returns an error if tx exceeds the maximum gas price,
+// fee or value configured. The fee checked is the most tx can be
+// charged, using the max fee per gas of EIP-1559 transactions.
+func (s *ConstructionAPIService) checkCaps(tx *ethTypes.Transaction) *types.Error {
+	gasPrice := tx.GasFeeCap()
+	if s.config.MaxGasPrice != nil && gasPrice.Cmp(s.config.MaxGasPrice) > 0 {
+		return wrapErr(
+			ErrGasPriceTooHigh,
+			fmt.Errorf("gas price %s exceeds the maximum of %s", gasPrice, s.config.MaxGasPrice),
+		)
+	}
+
+	fee := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(tx.Gas()))
+	if s.config.MaxFee != nil && fee.Cmp(s.config.MaxFee) > 0 {
+		return wrapErr(
+			ErrFeeTooHigh,
+			fmt.Errorf("fee %s exceeds the maximum of %s", fee, s.config.MaxFee),
+		)
+	}
+
+	if s.config.MaxValue != nil && tx.Value().Cmp(s.config.MaxValue) > 0 {
+		return wrapErr(
+			ErrValueTooHigh,
+			fmt.Errorf("value %s exceeds the maximum of %s", tx.Value(), s.config.MaxValue),
+		)
+	}
+
+	return nil
+}
+
+// bumpGasPrice returns the minimum gas price gmet accepts
+// to replace a transaction paying gasPrice.
+func bumpGasPrice(gasPrice *big.Int) *big.Int {
+	bumped := new(big.Int).Mul(gasPrice, big.NewInt(100+metadium.TxPoolPriceBump)) // nolint:gomnd
+
+	return bumped.Div(bumped, big.NewInt(100)) // nolint:gomnd
+}
+
+// newEthTransaction builds the go-ethereum transaction described by
+// unsignedTx. A DynamicFeeTx is built when the fee caps are set, an
+// AccessListTx when only an access list is set, otherwise a legacy
+// transaction is built.
+func newEthTransaction(unsignedTx *transaction) *ethTypes.Transaction {
+	// Contract deployments have no recipient.
+	to := recipient(unsignedTx.To)
+
+	if unsignedTx.isDynamicFee() {
+		return ethTypes.NewTx(&ethTypes.DynamicFeeTx{
+			ChainID:    unsignedTx.ChainID,
+			Nonce:      unsignedTx.Nonce,
+			GasTipCap:  unsignedTx.GasTipCap,
+			GasFeeCap:  unsignedTx.GasFeeCap,
+			Gas:        unsignedTx.GasLimit,
+			To:         to,
+			Value:      unsignedTx.Value,
+			Data:       unsignedTx.Data,
+			AccessList: unsignedTx.AccessList,
+		})
+	}
+
+	if unsignedTx.hasAccessList() {
+		return ethTypes.NewTx(&ethTypes.AccessListTx{
+			ChainID:    unsignedTx.ChainID,
+			Nonce:      unsignedTx.Nonce,
+			GasPrice:   unsignedTx.GasPrice,
+			Gas:        unsignedTx.GasLimit,
+			To:         to,
+			Value:      unsignedTx.Value,
+			Data:       unsignedTx.Data,
+			AccessList: unsignedTx.AccessList,
+		})
+	}
+
+	return ethTypes.NewTx(&ethTypes.LegacyTx{
+		Nonce:    unsignedTx.Nonce,
+		GasPrice: unsignedTx.GasPrice,
+		Gas:      unsignedTx.GasLimit,
+		To:       to,
+		Value:    unsignedTx.Value,
+		Data:     unsignedTx.Data,
+	})
+}
+
+// recipient returns the address of to, or nil
+// if to is empty, as for contract deployments.
+func recipient(to string) *common.Address {
+	if len(to) == 0 {
+		return nil
+	}
+
+	address := common.HexToAddress(to)
+	return &address
+}
+
+// newFeeDelegatedTransaction signs the sender transaction of unsignedTx
+// with senderSig and wraps it in a fee-delegated transaction that still
+// needs to be signed by the fee payer.
+func newFeeDelegatedTransaction(
+	unsignedTx *transaction,
+	senderSig []byte,
+) (*metadium.FeeDelegatedTransaction, error) {
+	signer := ethTypes.NewLondonSigner(unsignedTx.ChainID)
+	senderTx, err := newEthTransaction(unsignedTx).WithSignature(signer, senderSig)
+	if err != nil {
+		return nil, err
+	}
+
+	return metadium.NewFeeDelegatedTransaction(senderTx, common.HexToAddress(unsignedTx.FeePayer))
+}
+
+// senderSignedTransaction wraps the sender transaction of unsignedTx,
+// signed with its SenderSignature, in a fee-delegated transaction
+// that still needs to be signed by the fee payer.
+func senderSignedTransaction(unsignedTx *transaction) (*metadium.FeeDelegatedTransaction, *types.Error) {
+	signer := ethTypes.NewLondonSigner(unsignedTx.ChainID)
+	senderSignature := &types.Signature{
+		SignatureType: unsignedTx.signatureType(),
+		Bytes:         unsignedTx.SenderSignature,
+	}
+	senderSig, err := recoverableSignature(
+		senderSignature,
+		signer.Hash(newEthTransaction(unsignedTx)),
+		unsignedTx.From,
+	)
+	if err != nil {
+		return nil, wrapErr(ErrSignatureInvalid, err)
+	}
+
+	feeDelegatedTx, err := newFeeDelegatedTransaction(unsignedTx, senderSig)
+	if err != nil {
+		return nil, wrapErr(ErrSignatureInvalid, err)
+	}
+
+	sender, err := feeDelegatedTx.Sender()
+	if err != nil {
+		return nil, wrapErr(ErrSignatureInvalid, err)
+	}
+
+	if sender != common.HexToAddress(unsignedTx.From) {
+		return nil, wrapErr(
+			ErrSignatureInvalid,
+			fmt.Errorf("sender signature is signed by %s, expected %s", sender.Hex(), unsignedTx.From),
+		)
+	}
+
+	return feeDelegatedTx, nil
+}
+
+// combineFeeDelegated attaches the signature of the current signing
+// round to a fee-delegated transaction. Without a SenderSignature, the
+// signature of the sender is added to unsignedTx, and the resulting
+// intermediate transaction is returned so the fee payer can request
+// its payload. Otherwise, the signature of the fee payer completes
+// the fee-delegated transaction.
+func combineFeeDelegated(
+	unsignedTx *transaction,
+	signatures []*types.Signature,
+) ([]byte, *types.Error) {
+	if len(signatures) != 1 {
+		return nil, wrapErr(
+			ErrSignatureInvalid,
+			fmt.Errorf("expected 1 signature, got %d signatures", len(signatures)),
+		)
+	}
+
+	if len(unsignedTx.SenderSignature) == 0 {
+		senderSignedTx := *unsignedTx
+		senderSignedTx.SenderSignature = signatures[0].Bytes
+		if _, rErr := senderSignedTransaction(&senderSignedTx); rErr != nil {
+			return nil, rErr
+		}
+
+		// senderSignedTransaction has recovered the sender, so
+		// only the public key of the signature is left to check.
+		sender := common.HexToAddress(unsignedTx.From)
+		if err := verifySigner(signatures[0], sender, unsignedTx.From); err != nil {
+			return nil, wrapErr(ErrSignatureInvalid, err)
+		}
+
+		senderSignedTxJSON, err := json.Marshal(&senderSignedTx)
+		if err != nil {
+			return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
+		}
+
+		return senderSignedTxJSON, nil
+	}
+
+	feeDelegatedTx, rErr := senderSignedTransaction(unsignedTx)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	feePayerHash, err := feeDelegatedTx.FeePayerHash()
 	if err != nil {
 		return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
 	}
 
-	var resp *types.ConstructionParseResponse
-	if request.Signed {
-		resp = &types.ConstructionParseResponse{
-			Operations: ops,
-			AccountIdentifierSigners: []*types.AccountIdentifier{
-				{
-					Address: checkFrom,
-				},
-			},
-			Metadata: metaMap,
+	feePayerSig, err := recoverableSignature(signatures[0], feePayerHash, unsignedTx.FeePayer)
+	if err != nil {
+		return nil, wrapErr(ErrSignatureInvalid, err)
+	}
+
+	signedTx, err := feeDelegatedTx.WithFeePayerSignature(feePayerSig)
+	if err != nil {
+		return nil, wrapErr(ErrSignatureInvalid, err)
+	}
+
+	// The fee payer signature only recovers to the fee payer
+	// if it was made over the same sender signature.
+	feePayer, err := signedTx.FeePayerSender()
+	if err != nil {
+		return nil, wrapErr(ErrSignatureInvalid, err)
+	}
+
+	if err := verifySigner(signatures[0], feePayer, unsignedTx.FeePayer); err != nil {
+		return nil, wrapErr(ErrSignatureInvalid, err)
+	}
+
+	signedTxJSON, err := encodeSignedTransaction(signedTx, unsignedTx)
+	if err != nil {
+		return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
+	}
+
+	return signedTxJSON, nil
+}
+
+// isSupportedSignatureType returns true if payloads can be
+// requested with signatureType. The empty type is the default.
+func isSupportedSignatureType(signatureType types.SignatureType) bool {
+	switch signatureType {
+	case "", types.EcdsaRecovery, types.Ecdsa:
+		return true
+	default:
+		return false
+	}
+}
+
+// recoverableSignature returns the bytes of signature, made over
+// hash by expected, with the recovery id appended. A 64-byte ecdsa
+// signature has no recovery id, so it is the candidate for which
+// the signer recovered from hash is expected.
+func recoverableSignature(
+	signature *types.Signature,
+	hash common.Hash,
+	expected string,
+) ([]byte, error) {
+	if signature.SignatureType != types.Ecdsa {
+		return signature.Bytes, nil
+	}
+
+	if len(signature.Bytes) != crypto.SignatureLength-1 {
+		return nil, fmt.Errorf(
+			"expected %d-byte ecdsa signature, got %d bytes",
+			crypto.SignatureLength-1,
+			len(signature.Bytes),
+		)
+	}
+
+	recoverable := make([]byte, crypto.SignatureLength)
+	copy(recoverable, signature.Bytes)
+	for _, v := range []byte{0, 1} {
+		recoverable[crypto.RecoveryIDOffset] = v
+
+		pubKey, err := crypto.SigToPub(hash.Bytes(), recoverable)
+		if err != nil {
+			continue
 		}
-	} else {
-		resp = &types.ConstructionParseResponse{
-			Operations:               ops,
-			AccountIdentifierSigners: []*types.AccountIdentifier{},
-			Metadata:                 metaMap,
+
+		if crypto.PubkeyToAddress(*pubKey) == common.HexToAddress(expected) {
+			return recoverable, nil
 		}
 	}
-	return resp, nil
+
+	return nil, fmt.Errorf("signature was not made by %s", expected)
 }
 
-// ConstructionSubmit implements the /construction/submit endpoint.
-func (s *ConstructionAPIService) ConstructionSubmit(
-	ctx context.Context,
-	request *types.ConstructionSubmitRequest,
-) (*types.TransactionIdentifierResponse, *types.Error) {
-	if s.config.Mode != configuration.Online {
-		return nil, ErrUnavailableOffline
+// verifySigner returns an error if signer, recovered from signature,
+// is not expected or if the public key of signature is not the key
+// of signer.
+func verifySigner(signature *types.Signature, signer common.Address, expected string) error {
+	if signer != common.HexToAddress(expected) {
+		return fmt.Errorf("signed by %s instead of %s", signer.Hex(), expected)
 	}
 
-	var signedTx ethTypes.Transaction
-	if err := signedTx.UnmarshalJSON([]byte(request.SignedTransaction)); err != nil {
-		return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
+	if signature.PublicKey == nil || len(signature.PublicKey.Bytes) == 0 {
+		return nil
+	}
+
+	pubKey, err := parsePublicKey(signature.PublicKey.Bytes)
+	if err != nil {
+		return fmt.Errorf("unable to parse public key: %w", err)
 	}
 
-	if err := s.client.SendTransaction(ctx, &signedTx); err != nil {
-		return nil, wrapErr(ErrBroadcastFailed, err)
+	if crypto.PubkeyToAddress(*pubKey) != signer {
+		return fmt.Errorf("public key does not belong to signer %s", signer.Hex())
 	}
 
-	txIdentifier := &types.TransactionIdentifier{
-		Hash: signedTx.Hash().Hex(),
+	return nil
+}
+
+// parsePublicKey parses a compressed or
+// uncompressed secp256k1 public key.
+func parsePublicKey(b []byte) (*ecdsa.PublicKey, error) {
+	if len(b) != 33 { // nolint:gomnd
+		return crypto.UnmarshalPubkey(b)
 	}
-	return &types.TransactionIdentifierResponse{
-		TransactionIdentifier: txIdentifier,
-	}, nil
+
+	return crypto.DecompressPubkey(b)
+}
+
+// checkRawTransaction returns an error if the signed transaction of
+// intent cannot be returned raw by /construction/combine. Raw
+// transactions carry no currency or method signature, so ERC-20
+// transfers and contract calls made with a method signature would
+// not parse back into their operations and metadata.
+func checkRawTransaction(intent *transferIntent, methodSignature string) *types.Error {
+	if intent.isToken() {
+		return wrapErr(
+			ErrUnclearIntent,
+			errors.New("ERC-20 transfers cannot be returned as raw transactions"),
+		)
+	}
+
+	if len(methodSignature) > 0 {
+		return wrapErr(
+			ErrUnclearIntent,
+			errors.New("contract calls with a method signature cannot be returned as raw transactions"),
+		)
+	}
+
+	return nil
+}
+
+// isRawTransaction returns true if data is a 0x-prefixed hex
+// encoded transaction, as sent with eth_sendRawTransaction.
+func isRawTransaction(data []byte) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(data), []byte("0x"))
+}
+
+// DecodeSignedTransaction decodes a signed transaction as accepted
+// by /construction/submit, either raw or as gmet JSON, without
+// connecting to gmet. For fee-delegated transactions, the sender
+// transaction is returned along with the fee-delegated transaction.
+func DecodeSignedTransaction(
+	data []byte,
+) (*ethTypes.Transaction, *metadium.FeeDelegatedTransaction, error) {
+	return unmarshalSignedTransaction(data)
+}
+
+// unmarshalSignedTransaction decodes a signed transaction, either
+// raw or as gmet JSON. For fee-delegated transactions, the sender
+// transaction is returned along with the fee-delegated transaction.
+func unmarshalSignedTransaction(
+	data []byte,
+) (*ethTypes.Transaction, *metadium.FeeDelegatedTransaction, error) {
+	if isRawTransaction(data) {
+		return unmarshalRawTransaction(string(bytes.TrimSpace(data)))
+	}
+
+	if metadium.IsFeeDelegated(data) {
+		feeDelegatedTx := new(metadium.FeeDelegatedTransaction)
+		if err := feeDelegatedTx.UnmarshalJSON(data); err != nil {
+			return nil, nil, err
+		}
+
+		return feeDelegatedTx.SenderTx, feeDelegatedTx, nil
+	}
+
+	signedTx := new(ethTypes.Transaction)
+	if err := signedTx.UnmarshalJSON(data); err != nil {
+		return nil, nil, err
+	}
+
+	return signedTx, nil, nil
+}
+
+// unmarshalRawTransaction decodes a hex encoded raw transaction:
+// a legacy RLP transaction or a typed transaction envelope.
+func unmarshalRawTransaction(
+	rawHex string,
+) (*ethTypes.Transaction, *metadium.FeeDelegatedTransaction, error) {
+	rawTx, err := hexutil.Decode(rawHex)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(rawTx) > 0 && rawTx[0] == metadium.FeeDelegateDynamicFeeTxType {
+		feeDelegatedTx := new(metadium.FeeDelegatedTransaction)
+		if err := feeDelegatedTx.UnmarshalBinary(rawTx); err != nil {
+			return nil, nil, err
+		}
+
+		return feeDelegatedTx.SenderTx, feeDelegatedTx, nil
+	}
+
+	signedTx := new(ethTypes.Transaction)
+	if err := signedTx.UnmarshalBinary(rawTx); err != nil {
+		return nil, nil, err
+	}
+
+	return signedTx, nil, nil
+}
+
+// encodeSignedTransaction encodes signedTx as hex encoded RLP
+// if unsignedTx requests it and as gmet JSON otherwise.
+func encodeSignedTransaction(
+	signedTx signedTransaction,
+	unsignedTx *transaction,
+) ([]byte, error) {
+	if !unsignedTx.RawTransaction {
+		return marshalSignedTransaction(signedTx, unsignedTx.signedExtra())
+	}
+
+	rawTx, err := signedTx.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(hexutil.Encode(rawTx)), nil
+}
+
+// marshalSignedTransaction encodes a signed transaction as
+// gmet JSON. The currency of an ERC-20 transfer and the method
+// of a contract call are attached so that /construction/parse
+// can recover them offline.
+func marshalSignedTransaction(
+	signedTx json.Marshaler,
+	extra *signedTransactionExtra,
+) ([]byte, error) {
+	signedTxJSON, err := signedTx.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	extraMap, err := marshalJSONMap(extra)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(extraMap) == 0 {
+		return signedTxJSON, nil
+	}
+
+	var signedTxMap map[string]interface{}
+	if err := json.Unmarshal(signedTxJSON, &signedTxMap); err != nil {
+		return nil, err
+	}
+	for k, v := range extraMap {
+		signedTxMap[k] = v
+	}
+
+	return json.Marshal(signedTxMap)
+}
+
+// parseContractCall returns the contract call described by input.
+// If input does not describe a call, the metadata of the operations
+// is checked instead. It returns nil if no contract call is described.
+func parseContractCall(
+	input *contractCall,
+	operations []*types.Operation,
+) (*contractCall, *types.Error) {
+	call := input
+	for _, op := range operations {
+		if call.isSet() {
+			break
+		}
+		if op.Metadata == nil {
+			continue
+		}
+
+		var opCall contractCall
+		if err := unmarshalJSONMap(op.Metadata, &opCall); err != nil {
+			return nil, wrapErr(ErrUnableToParseIntermediateResult, err)
+		}
+		call = &opCall
+	}
+
+	if !call.isSet() {
+		return nil, nil
+	}
+
+	if len(call.MethodSignature) > 0 && len(call.Data) > 0 {
+		return nil, wrapErr(
+			ErrUnclearIntent,
+			fmt.Errorf("only one of %s and %s can be provided", metadium.MethodSignatureKey, metadium.CallDataKey),
+		)
+	}
+
+	return call, nil
 }
@@ -16,20 +16,26 @@ package services
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/metadium/rosetta-metadium/configuration"
 	"github.com/metadium/rosetta-metadium/metadium"
 	mocks "github.com/metadium/rosetta-metadium/mocks/services"
 	// "github.com/metadium/rosetta-metadium/params"
 
+	"github.com/coinbase/rosetta-sdk-go/parser"
 	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/stretchr/testify/assert"
@@ -72,7 +78,7 @@ func TestConstructionService(t *testing.T) {
 	}
 
 	mockClient := &mocks.Client{}
-	servicer := NewConstructionAPIService(cfg, mockClient)
+	servicer := NewConstructionAPIService(cfg, mockClient, NewTransactionTracker(cfg, mockClient))
 	ctx := context.Background()
 
 	// Test Derive
@@ -115,18 +121,19 @@ func TestConstructionService(t *testing.T) {
 	)
 	log(t, "preprocessResponse", preprocessResponse)
 	assert.Nil(t, err)
-	optionsRaw := `{"from":"0xbe862AD9AbFe6f22BCb087716c7D89a26051f74C"}`
+	optionsRaw := `{"from":"0xbe862AD9AbFe6f22BCb087716c7D89a26051f74C","to":"0x57B414a0332B5CaB885a451c2a28a07d1e9b8a8d","value":"0x9864aac3510d02"}` // nolint
 	var options options
 	assert.NoError(t, json.Unmarshal([]byte(optionsRaw), &options))
 	log(t, "options", options)
 	assert.Equal(t, &types.ConstructionPreprocessResponse{
-		Options: forceMarshalMap(t, options),
+		Options: forceMarshalMap(t, &options),
 	}, preprocessResponse)
 
 	// Test Metadata
 	metadata := &metadata{
 		GasPrice: big.NewInt(80000000000),
 		Nonce:    0,
+		GasLimit: 21000,
 	}
 
 	mockClient.On(
@@ -144,9 +151,30 @@ func TestConstructionService(t *testing.T) {
 		uint64(0),
 		nil,
 	).Once()
+	recipient := common.HexToAddress("0x57B414a0332B5CaB885a451c2a28a07d1e9b8a8d")
+	mockClient.On(
+		"EstimateGas",
+		ctx,
+		ethereum.CallMsg{
+			From:  common.HexToAddress("0xbe862AD9AbFe6f22BCb087716c7D89a26051f74C"),
+			To:    &recipient,
+			Value: big.NewInt(42894881044106498),
+		},
+	).Return(
+		uint64(21000),
+		nil,
+	).Once()
+	mockClient.On(
+		"HeaderByNumber",
+		ctx,
+		(*big.Int)(nil),
+	).Return(
+		&ethTypes.Header{Number: big.NewInt(100)},
+		nil,
+	).Once()
 	metadataResponse, err := servicer.ConstructionMetadata(ctx, &types.ConstructionMetadataRequest{
 		NetworkIdentifier: networkIdentifier,
-		Options:           forceMarshalMap(t, options),
+		Options:           forceMarshalMap(t, &options),
 	})
 	assert.Nil(t, err)
 	assert.Equal(t, &types.ConstructionMetadataResponse{
@@ -302,3 +330,3057 @@ func TestConstructionService(t *testing.T) {
 
 	mockClient.AssertExpectations(t)
 }
+
+func TestConstructionService_ERC20(t *testing.T) {
+	networkIdentifier = &types.NetworkIdentifier{
+		Network:    metadium.TestnetNetwork,
+		Blockchain: metadium.Blockchain,
+	}
+
+	cfg := &configuration.Configuration{
+		Mode:           configuration.Online,
+		Network:        networkIdentifier,
+		Params:         params.MetadiumTestnetChainConfig,
+		GasLimitMargin: 20,
+	}
+
+	mockClient := &mocks.Client{}
+	servicer := NewConstructionAPIService(cfg, mockClient, NewTransactionTracker(cfg, mockClient))
+	ctx := context.Background()
+
+	privateKey, keyErr := crypto.HexToECDSA(
+		"b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291",
+	)
+	assert.NoError(t, keyErr)
+	from := crypto.PubkeyToAddress(privateKey.PublicKey).Hex()
+	to := "0x57B414a0332B5CaB885a451c2a28a07d1e9b8a8d"
+	tokenAddress := "0x2d74530C0C196De44d3906822053bf336F18a16e"
+	currency := &types.Currency{
+		Symbol:   "TKN",
+		Decimals: 18,
+		Metadata: map[string]interface{}{
+			metadium.ContractAddressKey: tokenAddress,
+		},
+	}
+
+	ops := []*types.Operation{
+		{
+			OperationIdentifier: &types.OperationIdentifier{Index: 0},
+			Type:                metadium.ERC20TransferOpType,
+			Account:             &types.AccountIdentifier{Address: from},
+			Amount:              &types.Amount{Value: "-1000", Currency: currency},
+		},
+		{
+			OperationIdentifier: &types.OperationIdentifier{Index: 1},
+			RelatedOperations:   []*types.OperationIdentifier{{Index: 0}},
+			Type:                metadium.ERC20TransferOpType,
+			Account:             &types.AccountIdentifier{Address: to},
+			Amount:              &types.Amount{Value: "1000", Currency: currency},
+		},
+	}
+	transferData := metadium.ERC20TransferData(common.HexToAddress(to), big.NewInt(1000))
+
+	// Test Preprocess
+	preprocessResponse, err := servicer.ConstructionPreprocess(
+		ctx,
+		&types.ConstructionPreprocessRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations:        ops,
+		},
+	)
+	assert.Nil(t, err)
+	options := &options{
+		From: from,
+		To:   tokenAddress,
+		Data: transferData,
+	}
+	assert.Equal(t, &types.ConstructionPreprocessResponse{
+		Options: forceMarshalMap(t, options),
+	}, preprocessResponse)
+
+	// Test Metadata
+	mockClient.On("SuggestGasPrice", ctx).Return(big.NewInt(80000000000), nil).Once()
+	mockClient.On("PendingNonceAt", ctx, common.HexToAddress(from)).Return(uint64(3), nil).Once()
+	mockClient.On("HeaderByNumber", ctx, (*big.Int)(nil)).Return(
+		&ethTypes.Header{Number: big.NewInt(100)},
+		nil,
+	).Once()
+	tokenAddr := common.HexToAddress(tokenAddress)
+	mockClient.On(
+		"EstimateGas",
+		ctx,
+		ethereum.CallMsg{
+			From: common.HexToAddress(from),
+			To:   &tokenAddr,
+			Data: transferData,
+		},
+	).Return(uint64(51000), nil).Once()
+	metadataResponse, err := servicer.ConstructionMetadata(ctx, &types.ConstructionMetadataRequest{
+		NetworkIdentifier: networkIdentifier,
+		Options:           forceMarshalMap(t, options),
+	})
+	assert.Nil(t, err)
+	metadata := &metadata{
+		GasPrice: big.NewInt(80000000000),
+		Nonce:    3,
+		GasLimit: 61200,
+		Data:     transferData,
+	}
+	assert.Equal(t, &types.ConstructionMetadataResponse{
+		Metadata: forceMarshalMap(t, metadata),
+		SuggestedFee: []*types.Amount{
+			{
+				Value:    "4896000000000000",
+				Currency: metadium.Currency,
+			},
+		},
+	}, metadataResponse)
+
+	// Test Payloads
+	payloadsResponse, err := servicer.ConstructionPayloads(ctx, &types.ConstructionPayloadsRequest{
+		NetworkIdentifier: networkIdentifier,
+		Operations:        ops,
+		Metadata:          forceMarshalMap(t, metadata),
+	})
+	assert.Nil(t, err)
+	var unsignedTx transaction
+	assert.NoError(t, json.Unmarshal([]byte(payloadsResponse.UnsignedTransaction), &unsignedTx))
+	assert.Equal(t, tokenAddress, unsignedTx.To)
+	assert.Equal(t, 0, unsignedTx.Value.Sign())
+	assert.Equal(t, transferData, unsignedTx.Data)
+	assert.Equal(t, uint64(61200), unsignedTx.GasLimit)
+	assert.Equal(t, currency, unsignedTx.Currency)
+	assert.Len(t, payloadsResponse.Payloads, 1)
+
+	// Test Preprocess (token transfer as CALL operations)
+	callOps := []*types.Operation{
+		{
+			OperationIdentifier: &types.OperationIdentifier{Index: 0},
+			Type:                metadium.CallOpType,
+			Account:             &types.AccountIdentifier{Address: from},
+			Amount:              &types.Amount{Value: "-1000", Currency: currency},
+		},
+		{
+			OperationIdentifier: &types.OperationIdentifier{Index: 1},
+			RelatedOperations:   []*types.OperationIdentifier{{Index: 0}},
+			Type:                metadium.CallOpType,
+			Account:             &types.AccountIdentifier{Address: to},
+			Amount:              &types.Amount{Value: "1000", Currency: currency},
+		},
+	}
+	_, err = servicer.ConstructionPreprocess(ctx, &types.ConstructionPreprocessRequest{
+		NetworkIdentifier: networkIdentifier,
+		Operations:        callOps,
+	})
+	assert.Equal(t, ErrUnclearIntent.Code, err.Code)
+
+	// Test Parse Unsigned
+	parseOps := []*types.Operation{
+		{
+			OperationIdentifier: &types.OperationIdentifier{Index: 0},
+			Type:                metadium.ERC20TransferOpType,
+			Account:             &types.AccountIdentifier{Address: from},
+			Amount:              &types.Amount{Value: "-1000", Currency: currency},
+		},
+		{
+			OperationIdentifier: &types.OperationIdentifier{Index: 1},
+			RelatedOperations:   []*types.OperationIdentifier{{Index: 0}},
+			Type:                metadium.ERC20TransferOpType,
+			Account:             &types.AccountIdentifier{Address: to},
+			Amount:              &types.Amount{Value: "1000", Currency: currency},
+		},
+	}
+	parseUnsignedResponse, err := servicer.ConstructionParse(ctx, &types.ConstructionParseRequest{
+		NetworkIdentifier: networkIdentifier,
+		Signed:            false,
+		Transaction:       payloadsResponse.UnsignedTransaction,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, parseOps, parseUnsignedResponse.Operations)
+
+	// Test Combine
+	signature, signErr := crypto.Sign(payloadsResponse.Payloads[0].Bytes, privateKey)
+	assert.NoError(t, signErr)
+	combineResponse, err := servicer.ConstructionCombine(ctx, &types.ConstructionCombineRequest{
+		NetworkIdentifier:   networkIdentifier,
+		UnsignedTransaction: payloadsResponse.UnsignedTransaction,
+		Signatures: []*types.Signature{
+			{
+				SigningPayload: payloadsResponse.Payloads[0],
+				PublicKey: &types.PublicKey{
+					Bytes:     crypto.CompressPubkey(&privateKey.PublicKey),
+					CurveType: types.Secp256k1,
+				},
+				SignatureType: types.EcdsaRecovery,
+				Bytes:         signature,
+			},
+		},
+	})
+	assert.Nil(t, err)
+
+	// Test Parse Signed
+	parseSignedResponse, err := servicer.ConstructionParse(ctx, &types.ConstructionParseRequest{
+		NetworkIdentifier: networkIdentifier,
+		Signed:            true,
+		Transaction:       combineResponse.SignedTransaction,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, parseOps, parseSignedResponse.Operations)
+	assert.Equal(t, []*types.AccountIdentifier{{Address: from}}, parseSignedResponse.AccountIdentifierSigners)
+
+	// The parsed operations match the operations /block returns
+	// for the transfer, after the fee and the call to the token
+	// contract.
+	blockOps := []*types.Operation{
+		{
+			OperationIdentifier: &types.OperationIdentifier{Index: 0},
+			Type:                metadium.FeeOpType,
+			Status:              types.String(metadium.SuccessStatus),
+			Account:             &types.AccountIdentifier{Address: from},
+			Amount:              &types.Amount{Value: "-4896000000000000", Currency: metadium.Currency},
+		},
+		{
+			OperationIdentifier: &types.OperationIdentifier{Index: 1},
+			Type:                metadium.CallOpType,
+			Status:              types.String(metadium.SuccessStatus),
+			Account:             &types.AccountIdentifier{Address: from},
+			Amount:              &types.Amount{Value: "0", Currency: metadium.Currency},
+		},
+		{
+			OperationIdentifier: &types.OperationIdentifier{Index: 2},
+			RelatedOperations:   []*types.OperationIdentifier{{Index: 1}},
+			Type:                metadium.CallOpType,
+			Status:              types.String(metadium.SuccessStatus),
+			Account:             &types.AccountIdentifier{Address: tokenAddress},
+			Amount:              &types.Amount{Value: "0", Currency: metadium.Currency},
+		},
+		{
+			OperationIdentifier: &types.OperationIdentifier{Index: 3},
+			Type:                metadium.ERC20TransferOpType,
+			Status:              types.String(metadium.SuccessStatus),
+			Account:             &types.AccountIdentifier{Address: from},
+			Amount:              &types.Amount{Value: "-1000", Currency: currency},
+		},
+		{
+			OperationIdentifier: &types.OperationIdentifier{Index: 4},
+			RelatedOperations:   []*types.OperationIdentifier{{Index: 3}},
+			Type:                metadium.ERC20TransferOpType,
+			Status:              types.String(metadium.SuccessStatus),
+			Account:             &types.AccountIdentifier{Address: to},
+			Amount:              &types.Amount{Value: "1000", Currency: currency},
+		},
+	}
+	blockParser := parser.New(nil, nil, nil)
+	assert.NoError(t, blockParser.ExpectedOperations(parseSignedResponse.Operations, blockOps, false, false))
+
+	// Test Hash
+	hashResponse, err := servicer.ConstructionHash(ctx, &types.ConstructionHashRequest{
+		NetworkIdentifier: networkIdentifier,
+		SignedTransaction: combineResponse.SignedTransaction,
+	})
+	assert.Nil(t, err)
+	assert.NotEmpty(t, hashResponse.TransactionIdentifier.Hash)
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestConstructionService_ContractCall(t *testing.T) {
+	networkIdentifier = &types.NetworkIdentifier{
+		Network:    metadium.TestnetNetwork,
+		Blockchain: metadium.Blockchain,
+	}
+
+	cfg := &configuration.Configuration{
+		Mode:    configuration.Online,
+		Network: networkIdentifier,
+		Params:  params.MetadiumTestnetChainConfig,
+	}
+
+	mockClient := &mocks.Client{}
+	servicer := NewConstructionAPIService(cfg, mockClient, NewTransactionTracker(cfg, mockClient))
+	ctx := context.Background()
+
+	privateKey, keyErr := crypto.HexToECDSA(
+		"b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291",
+	)
+	assert.NoError(t, keyErr)
+	from := crypto.PubkeyToAddress(privateKey.PublicKey).Hex()
+	contract := "0x2d74530C0C196De44d3906822053bf336F18a16e"
+
+	ops := []*types.Operation{
+		{
+			OperationIdentifier: &types.OperationIdentifier{Index: 0},
+			Type:                metadium.CallOpType,
+			Account:             &types.AccountIdentifier{Address: from},
+			Amount:              &types.Amount{Value: "0", Currency: metadium.Currency},
+		},
+		{
+			OperationIdentifier: &types.OperationIdentifier{Index: 1},
+			RelatedOperations:   []*types.OperationIdentifier{{Index: 0}},
+			Type:                metadium.CallOpType,
+			Account:             &types.AccountIdentifier{Address: contract},
+			Amount:              &types.Amount{Value: "0", Currency: metadium.Currency},
+		},
+	}
+	methodSignature := "vote(uint256,bool)"
+	methodArgs := []string{"1", "true"}
+	callData, callErr := metadium.ContractCallData(methodSignature, methodArgs)
+	assert.NoError(t, callErr)
+
+	// Test Preprocess
+	preprocessResponse, err := servicer.ConstructionPreprocess(
+		ctx,
+		&types.ConstructionPreprocessRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations:        ops,
+			Metadata: map[string]interface{}{
+				"method_signature": methodSignature,
+				"method_args":      methodArgs,
+			},
+		},
+	)
+	assert.Nil(t, err)
+	callOptions := &options{
+		From:            from,
+		To:              contract,
+		Data:            callData,
+		MethodSignature: methodSignature,
+		MethodArgs:      methodArgs,
+	}
+	assert.Equal(t, &types.ConstructionPreprocessResponse{
+		Options: forceMarshalMap(t, callOptions),
+	}, preprocessResponse)
+
+	// Test Metadata
+	mockClient.On("SuggestGasPrice", ctx).Return(big.NewInt(80000000000), nil).Once()
+	mockClient.On("PendingNonceAt", ctx, common.HexToAddress(from)).Return(uint64(3), nil).Once()
+	mockClient.On("HeaderByNumber", ctx, (*big.Int)(nil)).Return(
+		&ethTypes.Header{Number: big.NewInt(100)},
+		nil,
+	).Once()
+	contractAddr := common.HexToAddress(contract)
+	mockClient.On(
+		"EstimateGas",
+		ctx,
+		ethereum.CallMsg{
+			From: common.HexToAddress(from),
+			To:   &contractAddr,
+			Data: callData,
+		},
+	).Return(uint64(50000), nil).Once()
+	metadataResponse, err := servicer.ConstructionMetadata(ctx, &types.ConstructionMetadataRequest{
+		NetworkIdentifier: networkIdentifier,
+		Options:           forceMarshalMap(t, callOptions),
+	})
+	assert.Nil(t, err)
+	metadata := &metadata{
+		GasPrice:        big.NewInt(80000000000),
+		Nonce:           3,
+		GasLimit:        50000,
+		Data:            callData,
+		MethodSignature: methodSignature,
+		MethodArgs:      methodArgs,
+	}
+	assert.Equal(t, forceMarshalMap(t, metadata), metadataResponse.Metadata)
+
+	// Test Payloads
+	payloadsResponse, err := servicer.ConstructionPayloads(ctx, &types.ConstructionPayloadsRequest{
+		NetworkIdentifier: networkIdentifier,
+		Operations:        ops,
+		Metadata:          forceMarshalMap(t, metadata),
+	})
+	assert.Nil(t, err)
+	var unsignedTx transaction
+	assert.NoError(t, json.Unmarshal([]byte(payloadsResponse.UnsignedTransaction), &unsignedTx))
+	assert.Equal(t, contract, unsignedTx.To)
+	assert.Equal(t, 0, unsignedTx.Value.Sign())
+	assert.Equal(t, callData, unsignedTx.Data)
+	assert.Equal(t, uint64(50000), unsignedTx.GasLimit)
+
+	// Test Parse Unsigned
+	parseOps := []*types.Operation{
+		ops[0],
+		{
+			OperationIdentifier: &types.OperationIdentifier{Index: 1},
+			RelatedOperations:   []*types.OperationIdentifier{{Index: 0}},
+			Type:                metadium.CallOpType,
+			Account:             &types.AccountIdentifier{Address: contract},
+			Amount:              &types.Amount{Value: "0", Currency: metadium.Currency},
+		},
+	}
+	parseMetadata := &parseMetadata{
+		Nonce:           3,
+		GasPrice:        big.NewInt(80000000000),
+		ChainID:         params.MetadiumTestnetChainConfig.ChainID,
+		MethodSignature: methodSignature,
+		MethodArgs:      methodArgs,
+	}
+	parseUnsignedResponse, err := servicer.ConstructionParse(ctx, &types.ConstructionParseRequest{
+		NetworkIdentifier: networkIdentifier,
+		Signed:            false,
+		Transaction:       payloadsResponse.UnsignedTransaction,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, parseOps, parseUnsignedResponse.Operations)
+	assert.Equal(t, forceMarshalMap(t, parseMetadata), parseUnsignedResponse.Metadata)
+
+	// Test Combine
+	signature, signErr := crypto.Sign(payloadsResponse.Payloads[0].Bytes, privateKey)
+	assert.NoError(t, signErr)
+	combineResponse, err := servicer.ConstructionCombine(ctx, &types.ConstructionCombineRequest{
+		NetworkIdentifier:   networkIdentifier,
+		UnsignedTransaction: payloadsResponse.UnsignedTransaction,
+		Signatures: []*types.Signature{
+			{
+				SigningPayload: payloadsResponse.Payloads[0],
+				PublicKey: &types.PublicKey{
+					Bytes:     crypto.CompressPubkey(&privateKey.PublicKey),
+					CurveType: types.Secp256k1,
+				},
+				SignatureType: types.EcdsaRecovery,
+				Bytes:         signature,
+			},
+		},
+	})
+	assert.Nil(t, err)
+
+	// Test Parse Signed
+	parseSignedResponse, err := servicer.ConstructionParse(ctx, &types.ConstructionParseRequest{
+		NetworkIdentifier: networkIdentifier,
+		Signed:            true,
+		Transaction:       combineResponse.SignedTransaction,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, parseOps, parseSignedResponse.Operations)
+	assert.Equal(t, forceMarshalMap(t, parseMetadata), parseSignedResponse.Metadata)
+
+	// Test Preprocess with raw calldata in the operation metadata
+	rawOps := []*types.Operation{
+		{
+			OperationIdentifier: ops[0].OperationIdentifier,
+			Type:                ops[0].Type,
+			Account:             ops[0].Account,
+			Amount:              ops[0].Amount,
+			Metadata: map[string]interface{}{
+				"data": hexutil.Encode(callData),
+			},
+		},
+		ops[1],
+	}
+	preprocessResponse, err = servicer.ConstructionPreprocess(
+		ctx,
+		&types.ConstructionPreprocessRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations:        rawOps,
+		},
+	)
+	assert.Nil(t, err)
+	rawOptions := &options{
+		From: from,
+		To:   contract,
+		Data: callData,
+	}
+	assert.Equal(t, &types.ConstructionPreprocessResponse{
+		Options: forceMarshalMap(t, rawOptions),
+	}, preprocessResponse)
+
+	// Test Preprocess with both a method signature and raw calldata
+	_, err = servicer.ConstructionPreprocess(
+		ctx,
+		&types.ConstructionPreprocessRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations:        rawOps,
+			Metadata: map[string]interface{}{
+				"method_signature": methodSignature,
+				"method_args":      methodArgs,
+				"data":             hexutil.Encode(callData),
+			},
+		},
+	)
+	assert.Equal(t, ErrUnclearIntent.Code, err.Code)
+
+	// Test Preprocess with arguments not matching the signature
+	_, err = servicer.ConstructionPreprocess(
+		ctx,
+		&types.ConstructionPreprocessRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations:        ops,
+			Metadata: map[string]interface{}{
+				"method_signature": methodSignature,
+				"method_args":      []string{"1"},
+			},
+		},
+	)
+	assert.Equal(t, ErrUnclearIntent.Code, err.Code)
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestConstructionService_Create(t *testing.T) {
+	networkIdentifier = &types.NetworkIdentifier{
+		Network:    metadium.TestnetNetwork,
+		Blockchain: metadium.Blockchain,
+	}
+
+	cfg := &configuration.Configuration{
+		Mode:    configuration.Online,
+		Network: networkIdentifier,
+		Params:  params.MetadiumTestnetChainConfig,
+	}
+
+	mockClient := &mocks.Client{}
+	servicer := NewConstructionAPIService(cfg, mockClient, NewTransactionTracker(cfg, mockClient))
+	ctx := context.Background()
+
+	privateKey, keyErr := crypto.HexToECDSA(
+		"b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291",
+	)
+	assert.NoError(t, keyErr)
+	from := crypto.PubkeyToAddress(privateKey.PublicKey).Hex()
+	contractAddress := crypto.CreateAddress(common.HexToAddress(from), 3).Hex()
+
+	ops := []*types.Operation{
+		{
+			OperationIdentifier: &types.OperationIdentifier{Index: 0},
+			Type:                metadium.CreateOpType,
+			Account:             &types.AccountIdentifier{Address: from},
+			Amount:              &types.Amount{Value: "-1000", Currency: metadium.Currency},
+		},
+	}
+	bytecode := "0x6080604052348015600f57600080fd5b50"
+	initCode, initErr := metadium.ContractCreationData(
+		hexutil.MustDecode(bytecode),
+		"constructor(uint256)",
+		[]string{"42"},
+	)
+	assert.NoError(t, initErr)
+
+	// Test Preprocess
+	preprocessResponse, err := servicer.ConstructionPreprocess(
+		ctx,
+		&types.ConstructionPreprocessRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations:        ops,
+			Metadata: map[string]interface{}{
+				"bytecode":              bytecode,
+				"constructor_signature": "constructor(uint256)",
+				"constructor_args":      []string{"42"},
+			},
+		},
+	)
+	assert.Nil(t, err)
+	options := &options{
+		From:  from,
+		Value: big.NewInt(1000),
+		Data:  initCode,
+	}
+	assert.Equal(t, &types.ConstructionPreprocessResponse{
+		Options: forceMarshalMap(t, options),
+	}, preprocessResponse)
+
+	// Test Metadata
+	mockClient.On("SuggestGasPrice", ctx).Return(big.NewInt(80000000000), nil).Once()
+	mockClient.On("PendingNonceAt", ctx, common.HexToAddress(from)).Return(uint64(3), nil).Once()
+	mockClient.On("HeaderByNumber", ctx, (*big.Int)(nil)).Return(
+		&ethTypes.Header{Number: big.NewInt(100)},
+		nil,
+	).Once()
+	mockClient.On(
+		"EstimateGas",
+		ctx,
+		ethereum.CallMsg{
+			From:  common.HexToAddress(from),
+			Value: big.NewInt(1000),
+			Data:  initCode,
+		},
+	).Return(uint64(100000), nil).Once()
+	metadataResponse, err := servicer.ConstructionMetadata(ctx, &types.ConstructionMetadataRequest{
+		NetworkIdentifier: networkIdentifier,
+		Options:           forceMarshalMap(t, options),
+	})
+	assert.Nil(t, err)
+	metadata := &metadata{
+		GasPrice:        big.NewInt(80000000000),
+		Nonce:           3,
+		GasLimit:        100000,
+		Data:            initCode,
+		ContractAddress: contractAddress,
+	}
+	assert.Equal(t, forceMarshalMap(t, metadata), metadataResponse.Metadata)
+
+	// Test Payloads
+	payloadsResponse, err := servicer.ConstructionPayloads(ctx, &types.ConstructionPayloadsRequest{
+		NetworkIdentifier: networkIdentifier,
+		Operations:        ops,
+		Metadata:          forceMarshalMap(t, metadata),
+	})
+	assert.Nil(t, err)
+	var unsignedTx transaction
+	assert.NoError(t, json.Unmarshal([]byte(payloadsResponse.UnsignedTransaction), &unsignedTx))
+	assert.Empty(t, unsignedTx.To)
+	assert.Equal(t, big.NewInt(1000), unsignedTx.Value)
+	assert.Equal(t, initCode, unsignedTx.Data)
+	assert.Equal(t, contractAddress, unsignedTx.ContractAddress)
+
+	// Test Parse Unsigned
+	parseMetadata := &parseMetadata{
+		Nonce:           3,
+		GasPrice:        big.NewInt(80000000000),
+		ChainID:         params.MetadiumTestnetChainConfig.ChainID,
+		Data:            initCode,
+		ContractAddress: contractAddress,
+	}
+	parseUnsignedResponse, err := servicer.ConstructionParse(ctx, &types.ConstructionParseRequest{
+		NetworkIdentifier: networkIdentifier,
+		Signed:            false,
+		Transaction:       payloadsResponse.UnsignedTransaction,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, ops, parseUnsignedResponse.Operations)
+	assert.Equal(t, forceMarshalMap(t, parseMetadata), parseUnsignedResponse.Metadata)
+
+	// Test Combine
+	signature, signErr := crypto.Sign(payloadsResponse.Payloads[0].Bytes, privateKey)
+	assert.NoError(t, signErr)
+	combineResponse, err := servicer.ConstructionCombine(ctx, &types.ConstructionCombineRequest{
+		NetworkIdentifier:   networkIdentifier,
+		UnsignedTransaction: payloadsResponse.UnsignedTransaction,
+		Signatures: []*types.Signature{
+			{
+				SigningPayload: payloadsResponse.Payloads[0],
+				PublicKey: &types.PublicKey{
+					Bytes:     crypto.CompressPubkey(&privateKey.PublicKey),
+					CurveType: types.Secp256k1,
+				},
+				SignatureType: types.EcdsaRecovery,
+				Bytes:         signature,
+			},
+		},
+	})
+	assert.Nil(t, err)
+
+	// Test Parse Signed
+	parseSignedResponse, err := servicer.ConstructionParse(ctx, &types.ConstructionParseRequest{
+		NetworkIdentifier: networkIdentifier,
+		Signed:            true,
+		Transaction:       combineResponse.SignedTransaction,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, ops, parseSignedResponse.Operations)
+	assert.Equal(t, []*types.AccountIdentifier{{Address: from}}, parseSignedResponse.AccountIdentifierSigners)
+	assert.Equal(t, forceMarshalMap(t, parseMetadata), parseSignedResponse.Metadata)
+
+	// Test Preprocess without bytecode
+	_, err = servicer.ConstructionPreprocess(
+		ctx,
+		&types.ConstructionPreprocessRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations:        ops,
+		},
+	)
+	assert.Equal(t, ErrUnclearIntent.Code, err.Code)
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestConstructionService_Replace(t *testing.T) {
+	networkIdentifier = &types.NetworkIdentifier{
+		Network:    metadium.TestnetNetwork,
+		Blockchain: metadium.Blockchain,
+	}
+
+	cfg := &configuration.Configuration{
+		Mode:    configuration.Online,
+		Network: networkIdentifier,
+		Params:  params.MetadiumTestnetChainConfig,
+	}
+
+	mockClient := &mocks.Client{}
+	servicer := NewConstructionAPIService(cfg, mockClient, NewTransactionTracker(cfg, mockClient))
+	ctx := context.Background()
+
+	from := "0x71562b71999873DB5b286dF957af199Ec94617F7"
+	to := "0x57B414a0332B5CaB885a451c2a28a07d1e9b8a8d"
+	transferOps := func(recipient string, amount int64) []*types.Operation {
+		return []*types.Operation{
+			{
+				OperationIdentifier: &types.OperationIdentifier{Index: 0},
+				Type:                metadium.CallOpType,
+				Account:             &types.AccountIdentifier{Address: from},
+				Amount:              &types.Amount{Value: big.NewInt(-amount).String(), Currency: metadium.Currency},
+			},
+			{
+				OperationIdentifier: &types.OperationIdentifier{Index: 1},
+				RelatedOperations:   []*types.OperationIdentifier{{Index: 0}},
+				Type:                metadium.CallOpType,
+				Account:             &types.AccountIdentifier{Address: recipient},
+				Amount:              &types.Amount{Value: big.NewInt(amount).String(), Currency: metadium.Currency},
+			},
+		}
+	}
+
+	// Test Preprocess (replace)
+	preprocessResponse, err := servicer.ConstructionPreprocess(
+		ctx,
+		&types.ConstructionPreprocessRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations:        transferOps(to, 1000),
+			Metadata: map[string]interface{}{
+				"nonce":   "0x3",
+				"replace": true,
+			},
+		},
+	)
+	assert.Nil(t, err)
+	nonce := uint64(3)
+	replaceOptions := &options{
+		From:    from,
+		To:      to,
+		Value:   big.NewInt(1000),
+		Nonce:   &nonce,
+		Replace: true,
+	}
+	assert.Equal(t, &types.ConstructionPreprocessResponse{
+		Options: forceMarshalMap(t, replaceOptions),
+	}, preprocessResponse)
+
+	// Test Metadata (replace)
+	pendingTx := ethTypes.NewTransaction(
+		3,
+		common.HexToAddress(to),
+		big.NewInt(1000),
+		21000,
+		big.NewInt(80000000000),
+		nil,
+	)
+	toAddr := common.HexToAddress(to)
+	mockClient.On("SuggestGasPrice", ctx).Return(big.NewInt(80000000000), nil).Once()
+	mockClient.On(
+		"EstimateGas",
+		ctx,
+		ethereum.CallMsg{
+			From:  common.HexToAddress(from),
+			To:    &toAddr,
+			Value: big.NewInt(1000),
+		},
+	).Return(uint64(21000), nil).Once()
+	mockClient.On("HeaderByNumber", ctx, (*big.Int)(nil)).Return(
+		&ethTypes.Header{Number: big.NewInt(100)},
+		nil,
+	).Once()
+	mockClient.On("PendingTransaction", ctx, common.HexToAddress(from), uint64(3)).Return(
+		pendingTx,
+		pendingTx.Hash(),
+		nil,
+	).Once()
+	metadataResponse, err := servicer.ConstructionMetadata(ctx, &types.ConstructionMetadataRequest{
+		NetworkIdentifier: networkIdentifier,
+		Options:           forceMarshalMap(t, replaceOptions),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, &types.ConstructionMetadataResponse{
+		Metadata: forceMarshalMap(t, &metadata{
+			GasPrice: big.NewInt(88000000000),
+			Nonce:    3,
+			GasLimit: 21000,
+		}),
+		SuggestedFee: []*types.Amount{
+			{
+				Value:    "1848000000000000",
+				Currency: metadium.Currency,
+			},
+		},
+	}, metadataResponse)
+
+	// Test Metadata (replace without a pending transaction)
+	mockClient.On("SuggestGasPrice", ctx).Return(big.NewInt(80000000000), nil).Once()
+	mockClient.On(
+		"EstimateGas",
+		ctx,
+		ethereum.CallMsg{
+			From:  common.HexToAddress(from),
+			To:    &toAddr,
+			Value: big.NewInt(1000),
+		},
+	).Return(uint64(21000), nil).Once()
+	mockClient.On("HeaderByNumber", ctx, (*big.Int)(nil)).Return(
+		&ethTypes.Header{Number: big.NewInt(100)},
+		nil,
+	).Once()
+	mockClient.On("PendingTransaction", ctx, common.HexToAddress(from), uint64(3)).Return(
+		nil,
+		common.Hash{},
+		metadium.ErrTransactionNotFound,
+	).Once()
+	_, err = servicer.ConstructionMetadata(ctx, &types.ConstructionMetadataRequest{
+		NetworkIdentifier: networkIdentifier,
+		Options:           forceMarshalMap(t, replaceOptions),
+	})
+	assert.Equal(t, ErrTransactionNotFound.Code, err.Code)
+
+	// Test Preprocess (cancel)
+	preprocessResponse, err = servicer.ConstructionPreprocess(
+		ctx,
+		&types.ConstructionPreprocessRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations:        transferOps(from, 0),
+			Metadata: map[string]interface{}{
+				"nonce":  "0x3",
+				"cancel": true,
+			},
+		},
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, &types.ConstructionPreprocessResponse{
+		Options: forceMarshalMap(t, &options{
+			From:    from,
+			To:      from,
+			Nonce:   &nonce,
+			Replace: true,
+		}),
+	}, preprocessResponse)
+
+	// Test Preprocess (cancel with value)
+	_, err = servicer.ConstructionPreprocess(
+		ctx,
+		&types.ConstructionPreprocessRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations:        transferOps(to, 1000),
+			Metadata: map[string]interface{}{
+				"nonce":  "0x3",
+				"cancel": true,
+			},
+		},
+	)
+	assert.Equal(t, ErrUnclearIntent.Code, err.Code)
+
+	// Test Preprocess (replace without nonce)
+	_, err = servicer.ConstructionPreprocess(
+		ctx,
+		&types.ConstructionPreprocessRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations:        transferOps(to, 1000),
+			Metadata: map[string]interface{}{
+				"replace": true,
+			},
+		},
+	)
+	assert.Equal(t, ErrUnclearIntent.Code, err.Code)
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestConstructionService_Batch(t *testing.T) {
+	networkIdentifier = &types.NetworkIdentifier{
+		Network:    metadium.TestnetNetwork,
+		Blockchain: metadium.Blockchain,
+	}
+
+	cfg := &configuration.Configuration{
+		Mode:    configuration.Online,
+		Network: networkIdentifier,
+		Params:  params.MetadiumTestnetChainConfig,
+	}
+
+	mockClient := &mocks.Client{}
+	servicer := NewConstructionAPIService(cfg, mockClient, NewTransactionTracker(cfg, mockClient))
+	ctx := context.Background()
+
+	privateKey, keyErr := crypto.HexToECDSA(
+		"b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291",
+	)
+	assert.NoError(t, keyErr)
+
+	from := "0x71562b71999873DB5b286dF957af199Ec94617F7"
+	recipients := []string{
+		"0x57B414a0332B5CaB885a451c2a28a07d1e9b8a8d",
+		"0x2d74530C0C196De44d3906822053bf336F18a16e",
+	}
+	amounts := []int64{1000, 2000}
+	var ops []*types.Operation
+	for i, recipient := range recipients {
+		index := int64(len(ops))
+		ops = append(ops,
+			&types.Operation{
+				OperationIdentifier: &types.OperationIdentifier{Index: index},
+				Type:                metadium.CallOpType,
+				Account:             &types.AccountIdentifier{Address: from},
+				Amount:              &types.Amount{Value: big.NewInt(-amounts[i]).String(), Currency: metadium.Currency},
+			},
+			&types.Operation{
+				OperationIdentifier: &types.OperationIdentifier{Index: index + 1},
+				RelatedOperations:   []*types.OperationIdentifier{{Index: index}},
+				Type:                metadium.CallOpType,
+				Account:             &types.AccountIdentifier{Address: recipient},
+				Amount:              &types.Amount{Value: big.NewInt(amounts[i]).String(), Currency: metadium.Currency},
+			},
+		)
+	}
+
+	// Test Preprocess
+	preprocessResponse, err := servicer.ConstructionPreprocess(
+		ctx,
+		&types.ConstructionPreprocessRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations:        ops,
+		},
+	)
+	assert.Nil(t, err)
+	batchOptions := &options{
+		From: from,
+		Batch: []*options{
+			{From: from, To: recipients[0], Value: big.NewInt(1000)},
+			{From: from, To: recipients[1], Value: big.NewInt(2000)},
+		},
+	}
+	assert.Equal(t, &types.ConstructionPreprocessResponse{
+		Options: forceMarshalMap(t, batchOptions),
+	}, preprocessResponse)
+
+	// Test Metadata
+	mockClient.On("SuggestGasPrice", ctx).Return(big.NewInt(80000000000), nil).Once()
+	mockClient.On("PendingNonceAt", ctx, common.HexToAddress(from)).Return(uint64(3), nil).Once()
+	for i, recipient := range recipients {
+		toAddr := common.HexToAddress(recipient)
+		mockClient.On(
+			"EstimateGas",
+			ctx,
+			ethereum.CallMsg{
+				From:  common.HexToAddress(from),
+				To:    &toAddr,
+				Value: big.NewInt(amounts[i]),
+			},
+		).Return(uint64(21000), nil).Once()
+	}
+	mockClient.On("HeaderByNumber", ctx, (*big.Int)(nil)).Return(
+		&ethTypes.Header{Number: big.NewInt(100)},
+		nil,
+	).Once()
+	metadataResponse, err := servicer.ConstructionMetadata(ctx, &types.ConstructionMetadataRequest{
+		NetworkIdentifier: networkIdentifier,
+		Options:           forceMarshalMap(t, batchOptions),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, &types.ConstructionMetadataResponse{
+		Metadata: forceMarshalMap(t, &metadata{
+			GasPrice: big.NewInt(80000000000),
+			Nonce:    3,
+			GasLimit: 21000,
+		}),
+		SuggestedFee: []*types.Amount{
+			{
+				Value:    "3360000000000000",
+				Currency: metadium.Currency,
+			},
+		},
+	}, metadataResponse)
+
+	// Test Payloads
+	payloadsResponse, err := servicer.ConstructionPayloads(ctx, &types.ConstructionPayloadsRequest{
+		NetworkIdentifier: networkIdentifier,
+		Operations:        ops,
+		Metadata:          metadataResponse.Metadata,
+	})
+	assert.Nil(t, err)
+	assert.Len(t, payloadsResponse.Payloads, len(recipients))
+
+	var unsignedTxs []*transaction
+	assert.NoError(t, json.Unmarshal([]byte(payloadsResponse.UnsignedTransaction), &unsignedTxs))
+	assert.Len(t, unsignedTxs, len(recipients))
+	for i, unsignedTx := range unsignedTxs {
+		assert.Equal(t, uint64(3+i), unsignedTx.Nonce)
+		assert.Equal(t, recipients[i], unsignedTx.To)
+		assert.Equal(t, big.NewInt(amounts[i]), unsignedTx.Value)
+		assert.Equal(t, from, payloadsResponse.Payloads[i].AccountIdentifier.Address)
+	}
+
+	// Test Parse Unsigned
+	parseUnsignedResponse, err := servicer.ConstructionParse(ctx, &types.ConstructionParseRequest{
+		NetworkIdentifier: networkIdentifier,
+		Signed:            false,
+		Transaction:       payloadsResponse.UnsignedTransaction,
+	})
+	assert.Nil(t, err)
+	assert.Len(t, parseUnsignedResponse.Operations, len(ops))
+	for i, op := range parseUnsignedResponse.Operations {
+		assert.Equal(t, ops[i].OperationIdentifier, op.OperationIdentifier)
+		assert.Equal(t, ops[i].RelatedOperations, op.RelatedOperations)
+		assert.Equal(t, ops[i].Account, op.Account)
+		assert.Equal(t, ops[i].Amount, op.Amount)
+	}
+	assert.Empty(t, parseUnsignedResponse.AccountIdentifierSigners)
+
+	// Test Combine with a missing signature
+	signatures := make([]*types.Signature, len(payloadsResponse.Payloads))
+	for i, payload := range payloadsResponse.Payloads {
+		signature, signErr := crypto.Sign(payload.Bytes, privateKey)
+		assert.NoError(t, signErr)
+		signatures[i] = &types.Signature{
+			SigningPayload: payload,
+			PublicKey: &types.PublicKey{
+				Bytes:     crypto.CompressPubkey(&privateKey.PublicKey),
+				CurveType: types.Secp256k1,
+			},
+			SignatureType: types.EcdsaRecovery,
+			Bytes:         signature,
+		}
+	}
+	_, err = servicer.ConstructionCombine(ctx, &types.ConstructionCombineRequest{
+		NetworkIdentifier:   networkIdentifier,
+		UnsignedTransaction: payloadsResponse.UnsignedTransaction,
+		Signatures:          signatures[:1],
+	})
+	assert.Equal(t, ErrSignatureInvalid.Code, err.Code)
+
+	// Test Combine
+	combineResponse, err := servicer.ConstructionCombine(ctx, &types.ConstructionCombineRequest{
+		NetworkIdentifier:   networkIdentifier,
+		UnsignedTransaction: payloadsResponse.UnsignedTransaction,
+		Signatures:          signatures,
+	})
+	assert.Nil(t, err)
+
+	// Test Parse Signed
+	parseSignedResponse, err := servicer.ConstructionParse(ctx, &types.ConstructionParseRequest{
+		NetworkIdentifier: networkIdentifier,
+		Signed:            true,
+		Transaction:       combineResponse.SignedTransaction,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, parseUnsignedResponse.Operations, parseSignedResponse.Operations)
+	assert.Equal(t, []*types.AccountIdentifier{{Address: from}}, parseSignedResponse.AccountIdentifierSigners)
+	assert.Len(t, parseSignedResponse.Metadata["transactions"], len(recipients))
+
+	// Test Hash
+	var signedTxs []json.RawMessage
+	assert.NoError(t, json.Unmarshal([]byte(combineResponse.SignedTransaction), &signedTxs))
+	hashes := make([]string, len(signedTxs))
+	for i, signedTx := range signedTxs {
+		hashResponse, err := servicer.ConstructionHash(ctx, &types.ConstructionHashRequest{
+			NetworkIdentifier: networkIdentifier,
+			SignedTransaction: string(signedTx),
+		})
+		assert.Nil(t, err)
+		hashes[i] = hashResponse.TransactionIdentifier.Hash
+	}
+	batchIdentifier := &types.TransactionIdentifierResponse{
+		TransactionIdentifier: &types.TransactionIdentifier{Hash: hashes[0]},
+		Metadata: map[string]interface{}{
+			"transaction_hashes": hashes,
+		},
+	}
+	hashResponse, err := servicer.ConstructionHash(ctx, &types.ConstructionHashRequest{
+		NetworkIdentifier: networkIdentifier,
+		SignedTransaction: combineResponse.SignedTransaction,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, batchIdentifier, hashResponse)
+
+	// Test Submit
+	mockClient.On("SendTransaction", ctx, mock.Anything).Return(nil).Twice()
+	submitResponse, err := servicer.ConstructionSubmit(ctx, &types.ConstructionSubmitRequest{
+		NetworkIdentifier: networkIdentifier,
+		SignedTransaction: combineResponse.SignedTransaction,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, batchIdentifier, submitResponse)
+
+	// Test Submit failing after the first transaction
+	mockClient.On("SendTransaction", ctx, mock.Anything).Return(nil).Once()
+	mockClient.On("SendTransaction", ctx, mock.Anything).Return(errors.New("nonce too low")).Once()
+	_, err = servicer.ConstructionSubmit(ctx, &types.ConstructionSubmitRequest{
+		NetworkIdentifier: networkIdentifier,
+		SignedTransaction: combineResponse.SignedTransaction,
+	})
+	assert.Equal(t, ErrNonceTooLow.Code, err.Code)
+	assert.Equal(t, 1, err.Details["submitted"])
+
+	// Test Preprocess with transfers from different senders
+	mixedOps := append([]*types.Operation{}, ops...)
+	mixedOps[2] = &types.Operation{
+		OperationIdentifier: ops[2].OperationIdentifier,
+		Type:                ops[2].Type,
+		Account:             &types.AccountIdentifier{Address: recipients[0]},
+		Amount:              ops[2].Amount,
+	}
+	_, err = servicer.ConstructionPreprocess(
+		ctx,
+		&types.ConstructionPreprocessRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations:        mixedOps,
+		},
+	)
+	assert.Equal(t, ErrUnclearIntent.Code, err.Code)
+
+	// Test Preprocess with a malformed transfer, which is not a batch
+	malformedOps := append([]*types.Operation{}, ops[:2]...)
+	malformedOps = append(malformedOps, &types.Operation{
+		OperationIdentifier: &types.OperationIdentifier{Index: 2},
+		Type:                metadium.CallOpType,
+		Account:             &types.AccountIdentifier{Address: recipients[1]},
+		Amount:              &types.Amount{Value: "1", Currency: metadium.Currency},
+	})
+	assert.False(t, isBatch(malformedOps))
+	_, err = servicer.ConstructionPreprocess(
+		ctx,
+		&types.ConstructionPreprocessRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations:        malformedOps,
+		},
+	)
+	assert.Equal(t, ErrUnclearIntent.Code, err.Code)
+	assert.NotContains(t, err.Details["context"], "batch")
+
+	// Test Payloads with a malformed transfer, which is not a batch
+	_, err = servicer.ConstructionPayloads(ctx, &types.ConstructionPayloadsRequest{
+		NetworkIdentifier: networkIdentifier,
+		Operations:        malformedOps,
+	})
+	assert.Equal(t, ErrUnclearIntent.Code, err.Code)
+	assert.NotContains(t, err.Details["context"], "batch")
+	assert.True(t, isBatch(ops))
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestConstructionService_EIP1559(t *testing.T) {
+	networkIdentifier = &types.NetworkIdentifier{
+		Network:    metadium.TestnetNetwork,
+		Blockchain: metadium.Blockchain,
+	}
+
+	londonConfig := *params.MetadiumTestnetChainConfig
+	londonConfig.BerlinBlock = big.NewInt(0)
+	londonConfig.LondonBlock = big.NewInt(0)
+	cfg := &configuration.Configuration{
+		Mode:    configuration.Online,
+		Network: networkIdentifier,
+		Params:  &londonConfig,
+	}
+
+	mockClient := &mocks.Client{}
+	servicer := NewConstructionAPIService(cfg, mockClient, NewTransactionTracker(cfg, mockClient))
+	ctx := context.Background()
+
+	privateKey, keyErr := crypto.HexToECDSA(
+		"b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291",
+	)
+	assert.NoError(t, keyErr)
+	from := crypto.PubkeyToAddress(privateKey.PublicKey).Hex()
+	to := "0x57B414a0332B5CaB885a451c2a28a07d1e9b8a8d"
+
+	ops := []*types.Operation{
+		{
+			OperationIdentifier: &types.OperationIdentifier{Index: 0},
+			Type:                metadium.CallOpType,
+			Account:             &types.AccountIdentifier{Address: from},
+			Amount:              &types.Amount{Value: "-1000", Currency: metadium.Currency},
+		},
+		{
+			OperationIdentifier: &types.OperationIdentifier{Index: 1},
+			RelatedOperations:   []*types.OperationIdentifier{{Index: 0}},
+			Type:                metadium.CallOpType,
+			Account:             &types.AccountIdentifier{Address: to},
+			Amount:              &types.Amount{Value: "1000", Currency: metadium.Currency},
+		},
+	}
+
+	// Test Metadata
+	mockClient.On("SuggestGasPrice", ctx).Return(big.NewInt(80000000000), nil).Once()
+	mockClient.On("PendingNonceAt", ctx, common.HexToAddress(from)).Return(uint64(3), nil).Once()
+	mockClient.On("HeaderByNumber", ctx, (*big.Int)(nil)).Return(
+		&ethTypes.Header{Number: big.NewInt(100), BaseFee: big.NewInt(80000000000)},
+		nil,
+	).Once()
+	mockClient.On("SuggestGasTipCap", ctx).Return(big.NewInt(1000000000), nil).Once()
+	metadataResponse, err := servicer.ConstructionMetadata(ctx, &types.ConstructionMetadataRequest{
+		NetworkIdentifier: networkIdentifier,
+		Options:           forceMarshalMap(t, &options{From: from}),
+	})
+	assert.Nil(t, err)
+	metadata := &metadata{
+		GasPrice:  big.NewInt(80000000000),
+		Nonce:     3,
+		GasLimit:  21000,
+		BaseFee:   big.NewInt(80000000000),
+		GasFeeCap: big.NewInt(161000000000),
+		GasTipCap: big.NewInt(1000000000),
+	}
+	assert.Equal(t, &types.ConstructionMetadataResponse{
+		Metadata: forceMarshalMap(t, metadata),
+		SuggestedFee: []*types.Amount{
+			{
+				Value:    "1701000000000000",
+				Currency: metadium.Currency,
+			},
+		},
+	}, metadataResponse)
+
+	// Test Payloads
+	payloadsResponse, err := servicer.ConstructionPayloads(ctx, &types.ConstructionPayloadsRequest{
+		NetworkIdentifier: networkIdentifier,
+		Operations:        ops,
+		Metadata:          forceMarshalMap(t, metadata),
+	})
+	assert.Nil(t, err)
+	var unsignedTx transaction
+	assert.NoError(t, json.Unmarshal([]byte(payloadsResponse.UnsignedTransaction), &unsignedTx))
+	assert.Equal(t, metadata.GasFeeCap, unsignedTx.GasFeeCap)
+	assert.Equal(t, metadata.GasTipCap, unsignedTx.GasTipCap)
+	assert.Len(t, payloadsResponse.Payloads, 1)
+
+	parseMetadata := &parseMetadata{
+		Nonce:     3,
+		GasPrice:  metadata.GasFeeCap,
+		ChainID:   big.NewInt(12),
+		GasFeeCap: metadata.GasFeeCap,
+		GasTipCap: metadata.GasTipCap,
+	}
+
+	// Test Parse Unsigned
+	parseUnsignedResponse, err := servicer.ConstructionParse(ctx, &types.ConstructionParseRequest{
+		NetworkIdentifier: networkIdentifier,
+		Signed:            false,
+		Transaction:       payloadsResponse.UnsignedTransaction,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, ops, parseUnsignedResponse.Operations)
+	assert.Equal(t, forceMarshalMap(t, parseMetadata), parseUnsignedResponse.Metadata)
+
+	// Test Combine
+	signature, signErr := crypto.Sign(payloadsResponse.Payloads[0].Bytes, privateKey)
+	assert.NoError(t, signErr)
+	combineResponse, err := servicer.ConstructionCombine(ctx, &types.ConstructionCombineRequest{
+		NetworkIdentifier:   networkIdentifier,
+		UnsignedTransaction: payloadsResponse.UnsignedTransaction,
+		Signatures: []*types.Signature{
+			{
+				SigningPayload: payloadsResponse.Payloads[0],
+				PublicKey: &types.PublicKey{
+					Bytes:     crypto.CompressPubkey(&privateKey.PublicKey),
+					CurveType: types.Secp256k1,
+				},
+				SignatureType: types.EcdsaRecovery,
+				Bytes:         signature,
+			},
+		},
+	})
+	assert.Nil(t, err)
+
+	var signedTx ethTypes.Transaction
+	assert.NoError(t, signedTx.UnmarshalJSON([]byte(combineResponse.SignedTransaction)))
+	assert.Equal(t, uint8(ethTypes.DynamicFeeTxType), signedTx.Type())
+
+	// Test Parse Signed
+	parseSignedResponse, err := servicer.ConstructionParse(ctx, &types.ConstructionParseRequest{
+		NetworkIdentifier: networkIdentifier,
+		Signed:            true,
+		Transaction:       combineResponse.SignedTransaction,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, &types.ConstructionParseResponse{
+		Operations:               ops,
+		AccountIdentifierSigners: []*types.AccountIdentifier{{Address: from}},
+		Metadata:                 forceMarshalMap(t, parseMetadata),
+	}, parseSignedResponse)
+
+	// Test Hash
+	hashResponse, err := servicer.ConstructionHash(ctx, &types.ConstructionHashRequest{
+		NetworkIdentifier: networkIdentifier,
+		SignedTransaction: combineResponse.SignedTransaction,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, signedTx.Hash().Hex(), hashResponse.TransactionIdentifier.Hash)
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestConstructionService_AccessList(t *testing.T) {
+	networkIdentifier = &types.NetworkIdentifier{
+		Network:    metadium.TestnetNetwork,
+		Blockchain: metadium.Blockchain,
+	}
+
+	privateKey, keyErr := crypto.HexToECDSA(
+		"b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291",
+	)
+	assert.NoError(t, keyErr)
+	from := crypto.PubkeyToAddress(privateKey.PublicKey).Hex()
+	to := "0x57B414a0332B5CaB885a451c2a28a07d1e9b8a8d"
+	toAddress := common.HexToAddress(to)
+	accessList := ethTypes.AccessList{
+		{
+			Address:     common.HexToAddress("0x4dDC2D193948926D02f9B1fE9e1daa0718270ED5"),
+			StorageKeys: []common.Hash{common.HexToHash("0x01")},
+		},
+	}
+
+	ops := []*types.Operation{
+		{
+			OperationIdentifier: &types.OperationIdentifier{Index: 0},
+			Type:                metadium.CallOpType,
+			Account:             &types.AccountIdentifier{Address: from},
+			Amount:              &types.Amount{Value: "-1000", Currency: metadium.Currency},
+		},
+		{
+			OperationIdentifier: &types.OperationIdentifier{Index: 1},
+			RelatedOperations:   []*types.OperationIdentifier{{Index: 0}},
+			Type:                metadium.CallOpType,
+			Account:             &types.AccountIdentifier{Address: to},
+			Amount:              &types.Amount{Value: "1000", Currency: metadium.Currency},
+		},
+	}
+
+	tests := map[string]struct {
+		londonBlock *big.Int
+		baseFee     *big.Int
+
+		expectedType uint8
+	}{
+		"eip-2930": {
+			expectedType: ethTypes.AccessListTxType,
+		},
+		"eip-1559": {
+			londonBlock:  big.NewInt(0),
+			baseFee:      big.NewInt(80000000000),
+			expectedType: ethTypes.DynamicFeeTxType,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			chainConfig := *params.MetadiumTestnetChainConfig
+			chainConfig.BerlinBlock = big.NewInt(0)
+			chainConfig.LondonBlock = test.londonBlock
+			cfg := &configuration.Configuration{
+				Mode:    configuration.Online,
+				Network: networkIdentifier,
+				Params:  &chainConfig,
+			}
+
+			mockClient := &mocks.Client{}
+			servicer := NewConstructionAPIService(cfg, mockClient, NewTransactionTracker(cfg, mockClient))
+			ctx := context.Background()
+
+			// Test Preprocess
+			preprocessResponse, err := servicer.ConstructionPreprocess(ctx, &types.ConstructionPreprocessRequest{
+				NetworkIdentifier: networkIdentifier,
+				Operations:        ops,
+				Metadata: map[string]interface{}{
+					"create_access_list": true,
+				},
+			})
+			assert.Nil(t, err)
+			options := &options{
+				From:             from,
+				To:               to,
+				Value:            big.NewInt(1000),
+				CreateAccessList: true,
+			}
+			assert.Equal(t, forceMarshalMap(t, options), preprocessResponse.Options)
+
+			// Test Metadata
+			mockClient.On("SuggestGasPrice", ctx).Return(big.NewInt(80000000000), nil).Once()
+			mockClient.On("PendingNonceAt", ctx, common.HexToAddress(from)).Return(uint64(3), nil).Once()
+			mockClient.On("CreateAccessList", ctx, ethereum.CallMsg{
+				From:  common.HexToAddress(from),
+				To:    &toAddress,
+				Value: big.NewInt(1000),
+			}).Return(accessList, nil).Once()
+			mockClient.On("EstimateGas", ctx, ethereum.CallMsg{
+				From:       common.HexToAddress(from),
+				To:         &toAddress,
+				Value:      big.NewInt(1000),
+				AccessList: accessList,
+			}).Return(uint64(25300), nil).Once()
+			mockClient.On("HeaderByNumber", ctx, (*big.Int)(nil)).Return(
+				&ethTypes.Header{Number: big.NewInt(100), BaseFee: test.baseFee},
+				nil,
+			).Once()
+			if test.baseFee != nil {
+				mockClient.On("SuggestGasTipCap", ctx).Return(big.NewInt(1000000000), nil).Once()
+			}
+			metadataResponse, err := servicer.ConstructionMetadata(ctx, &types.ConstructionMetadataRequest{
+				NetworkIdentifier: networkIdentifier,
+				Options:           preprocessResponse.Options,
+			})
+			assert.Nil(t, err)
+			var metadata metadata
+			assert.NoError(t, unmarshalJSONMap(metadataResponse.Metadata, &metadata))
+			assert.Equal(t, accessList, metadata.AccessList)
+			assert.Equal(t, uint64(25300), metadata.GasLimit)
+
+			// Test Payloads
+			payloadsResponse, err := servicer.ConstructionPayloads(ctx, &types.ConstructionPayloadsRequest{
+				NetworkIdentifier: networkIdentifier,
+				Operations:        ops,
+				Metadata:          metadataResponse.Metadata,
+			})
+			assert.Nil(t, err)
+			var unsignedTx transaction
+			assert.NoError(t, json.Unmarshal([]byte(payloadsResponse.UnsignedTransaction), &unsignedTx))
+			assert.Equal(t, accessList, unsignedTx.AccessList)
+
+			parseMetadata := &parseMetadata{
+				Nonce:      3,
+				GasPrice:   unsignedTx.GasPrice,
+				ChainID:    big.NewInt(12),
+				GasFeeCap:  metadata.GasFeeCap,
+				GasTipCap:  metadata.GasTipCap,
+				AccessList: accessList,
+			}
+
+			// Test Parse Unsigned
+			parseUnsignedResponse, err := servicer.ConstructionParse(ctx, &types.ConstructionParseRequest{
+				NetworkIdentifier: networkIdentifier,
+				Signed:            false,
+				Transaction:       payloadsResponse.UnsignedTransaction,
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, ops, parseUnsignedResponse.Operations)
+			assert.Equal(t, forceMarshalMap(t, parseMetadata), parseUnsignedResponse.Metadata)
+
+			// Test Combine
+			signature, signErr := crypto.Sign(payloadsResponse.Payloads[0].Bytes, privateKey)
+			assert.NoError(t, signErr)
+			combineResponse, err := servicer.ConstructionCombine(ctx, &types.ConstructionCombineRequest{
+				NetworkIdentifier:   networkIdentifier,
+				UnsignedTransaction: payloadsResponse.UnsignedTransaction,
+				Signatures: []*types.Signature{
+					{
+						SigningPayload: payloadsResponse.Payloads[0],
+						PublicKey: &types.PublicKey{
+							Bytes:     crypto.CompressPubkey(&privateKey.PublicKey),
+							CurveType: types.Secp256k1,
+						},
+						SignatureType: types.EcdsaRecovery,
+						Bytes:         signature,
+					},
+				},
+			})
+			assert.Nil(t, err)
+
+			var signedTx ethTypes.Transaction
+			assert.NoError(t, signedTx.UnmarshalJSON([]byte(combineResponse.SignedTransaction)))
+			assert.Equal(t, test.expectedType, signedTx.Type())
+			assert.Equal(t, accessList, signedTx.AccessList())
+
+			// Test Parse Signed
+			parseSignedResponse, err := servicer.ConstructionParse(ctx, &types.ConstructionParseRequest{
+				NetworkIdentifier: networkIdentifier,
+				Signed:            true,
+				Transaction:       combineResponse.SignedTransaction,
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, &types.ConstructionParseResponse{
+				Operations:               ops,
+				AccountIdentifierSigners: []*types.AccountIdentifier{{Address: from}},
+				Metadata:                 forceMarshalMap(t, parseMetadata),
+			}, parseSignedResponse)
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+
+	// Test Preprocess (access list provided and created)
+	cfg := &configuration.Configuration{
+		Mode:    configuration.Offline,
+		Network: networkIdentifier,
+		Params:  params.MetadiumTestnetChainConfig,
+	}
+	servicer := NewConstructionAPIService(cfg, nil, nil)
+	preprocessResponse, err := servicer.ConstructionPreprocess(
+		context.Background(),
+		&types.ConstructionPreprocessRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations:        ops,
+			Metadata: map[string]interface{}{
+				"access_list":        accessList,
+				"create_access_list": true,
+			},
+		},
+	)
+	assert.Nil(t, preprocessResponse)
+	assert.Equal(t, ErrUnclearIntent.Code, err.Code)
+
+	// Test Metadata (access lists before Berlin)
+	cfg = &configuration.Configuration{
+		Mode:    configuration.Online,
+		Network: networkIdentifier,
+		Params:  params.MetadiumTestnetChainConfig,
+	}
+	mockClient := &mocks.Client{}
+	servicer = NewConstructionAPIService(cfg, mockClient, NewTransactionTracker(cfg, mockClient))
+	ctx := context.Background()
+	for _, input := range []*options{
+		{From: from, To: to, Value: big.NewInt(1000), AccessList: accessList},
+		{From: from, To: to, Value: big.NewInt(1000), CreateAccessList: true},
+	} {
+		mockClient.On("SuggestGasPrice", ctx).Return(big.NewInt(80000000000), nil).Once()
+		mockClient.On("PendingNonceAt", ctx, common.HexToAddress(from)).Return(uint64(3), nil).Once()
+		mockClient.On("HeaderByNumber", ctx, (*big.Int)(nil)).Return(
+			&ethTypes.Header{Number: big.NewInt(100)},
+			nil,
+		).Once()
+		metadataResponse, err := servicer.ConstructionMetadata(ctx, &types.ConstructionMetadataRequest{
+			NetworkIdentifier: networkIdentifier,
+			Options:           forceMarshalMap(t, input),
+		})
+		assert.Nil(t, metadataResponse)
+		assert.Equal(t, ErrUnclearIntent.Code, err.Code)
+	}
+	mockClient.AssertExpectations(t)
+}
+
+func TestConstructionService_FeeDelegated(t *testing.T) {
+	networkIdentifier = &types.NetworkIdentifier{
+		Network:    metadium.TestnetNetwork,
+		Blockchain: metadium.Blockchain,
+	}
+
+	cfg := &configuration.Configuration{
+		Mode:    configuration.Online,
+		Network: networkIdentifier,
+		Params:  params.MetadiumTestnetChainConfig,
+	}
+
+	mockClient := &mocks.Client{}
+	servicer := NewConstructionAPIService(cfg, mockClient, NewTransactionTracker(cfg, mockClient))
+	ctx := context.Background()
+
+	senderKey, keyErr := crypto.HexToECDSA(
+		"b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291",
+	)
+	assert.NoError(t, keyErr)
+	feePayerKey, keyErr := crypto.HexToECDSA(
+		"8a1f9a8f95be41cd7ccb6168179afb4504aefe388d1e14474d32c45c72ce7b7a",
+	)
+	assert.NoError(t, keyErr)
+	from := crypto.PubkeyToAddress(senderKey.PublicKey).Hex()
+	feePayer := crypto.PubkeyToAddress(feePayerKey.PublicKey).Hex()
+	to := "0x57B414a0332B5CaB885a451c2a28a07d1e9b8a8d"
+
+	ops := []*types.Operation{
+		{
+			OperationIdentifier: &types.OperationIdentifier{Index: 0},
+			Type:                metadium.CallOpType,
+			Account:             &types.AccountIdentifier{Address: from},
+			Amount:              &types.Amount{Value: "-1000", Currency: metadium.Currency},
+		},
+		{
+			OperationIdentifier: &types.OperationIdentifier{Index: 1},
+			RelatedOperations:   []*types.OperationIdentifier{{Index: 0}},
+			Type:                metadium.CallOpType,
+			Account:             &types.AccountIdentifier{Address: to},
+			Amount:              &types.Amount{Value: "1000", Currency: metadium.Currency},
+		},
+	}
+
+	// Test Preprocess
+	preprocessResponse, err := servicer.ConstructionPreprocess(
+		ctx,
+		&types.ConstructionPreprocessRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations:        ops,
+			Metadata: map[string]interface{}{
+				"fee_payer": feePayer,
+			},
+		},
+	)
+	assert.Nil(t, err)
+	options := &options{
+		From:     from,
+		To:       to,
+		Value:    big.NewInt(1000),
+		FeePayer: feePayer,
+	}
+	assert.Equal(t, &types.ConstructionPreprocessResponse{
+		Options: forceMarshalMap(t, options),
+	}, preprocessResponse)
+
+	// Test Metadata
+	mockClient.On("SuggestGasPrice", ctx).Return(big.NewInt(80000000000), nil).Once()
+	mockClient.On("PendingNonceAt", ctx, common.HexToAddress(from)).Return(uint64(3), nil).Once()
+	mockClient.On("HeaderByNumber", ctx, (*big.Int)(nil)).Return(
+		&ethTypes.Header{Number: big.NewInt(100)},
+		nil,
+	).Once()
+	toAddr := common.HexToAddress(to)
+	mockClient.On(
+		"EstimateGas",
+		ctx,
+		ethereum.CallMsg{
+			From:  common.HexToAddress(from),
+			To:    &toAddr,
+			Value: big.NewInt(1000),
+		},
+	).Return(uint64(21000), nil).Once()
+	metadataResponse, err := servicer.ConstructionMetadata(ctx, &types.ConstructionMetadataRequest{
+		NetworkIdentifier: networkIdentifier,
+		Options:           forceMarshalMap(t, options),
+	})
+	assert.Nil(t, err)
+	metadata := &metadata{
+		GasPrice: big.NewInt(80000000000),
+		Nonce:    3,
+		GasLimit: 21000,
+		FeePayer: feePayer,
+	}
+	assert.Equal(t, forceMarshalMap(t, metadata), metadataResponse.Metadata)
+
+	// Test Payloads before the sender has signed
+	payloadsResponse, err := servicer.ConstructionPayloads(ctx, &types.ConstructionPayloadsRequest{
+		NetworkIdentifier: networkIdentifier,
+		Operations:        ops,
+		Metadata:          metadataResponse.Metadata,
+	})
+	assert.Nil(t, err)
+	assert.Len(t, payloadsResponse.Payloads, 1)
+	assert.Equal(t, from, payloadsResponse.Payloads[0].AccountIdentifier.Address)
+
+	senderSignature, signErr := crypto.Sign(payloadsResponse.Payloads[0].Bytes, senderKey)
+	assert.NoError(t, signErr)
+	senderSignatures := []*types.Signature{
+		{
+			SigningPayload: payloadsResponse.Payloads[0],
+			PublicKey: &types.PublicKey{
+				Bytes:     crypto.CompressPubkey(&senderKey.PublicKey),
+				CurveType: types.Secp256k1,
+			},
+			SignatureType: types.EcdsaRecovery,
+			Bytes:         senderSignature,
+		},
+	}
+
+	// Test Combine with more than 1 signature per round
+	_, err = servicer.ConstructionCombine(ctx, &types.ConstructionCombineRequest{
+		NetworkIdentifier:   networkIdentifier,
+		UnsignedTransaction: payloadsResponse.UnsignedTransaction,
+		Signatures:          append(senderSignatures, senderSignatures...),
+	})
+	assert.Equal(t, ErrSignatureInvalid.Code, err.Code)
+
+	// Test Combine with the sender signature
+	senderCombineResponse, err := servicer.ConstructionCombine(ctx, &types.ConstructionCombineRequest{
+		NetworkIdentifier:   networkIdentifier,
+		UnsignedTransaction: payloadsResponse.UnsignedTransaction,
+		Signatures:          senderSignatures,
+	})
+	assert.Nil(t, err)
+
+	var senderSignedTx transaction
+	assert.NoError(t, json.Unmarshal([]byte(senderCombineResponse.SignedTransaction), &senderSignedTx))
+	assert.Equal(t, senderSignature, senderSignedTx.SenderSignature)
+
+	// The sender-signed transaction is not ready to be submitted
+	_, err = servicer.ConstructionHash(ctx, &types.ConstructionHashRequest{
+		NetworkIdentifier: networkIdentifier,
+		SignedTransaction: senderCombineResponse.SignedTransaction,
+	})
+	assert.NotNil(t, err)
+
+	// Test Payloads once the sender has signed
+	metadata.SenderSignature = senderSignedTx.SenderSignature
+	payloadsResponse, err = servicer.ConstructionPayloads(ctx, &types.ConstructionPayloadsRequest{
+		NetworkIdentifier: networkIdentifier,
+		Operations:        ops,
+		Metadata:          forceMarshalMap(t, metadata),
+	})
+	assert.Nil(t, err)
+	assert.JSONEq(t, senderCombineResponse.SignedTransaction, payloadsResponse.UnsignedTransaction)
+	assert.Len(t, payloadsResponse.Payloads, 1)
+	assert.Equal(t, feePayer, payloadsResponse.Payloads[0].AccountIdentifier.Address)
+
+	feePayerSignature, signErr := crypto.Sign(payloadsResponse.Payloads[0].Bytes, feePayerKey)
+	assert.NoError(t, signErr)
+
+	// Test Parse Unsigned
+	parseMetadata := &parseMetadata{
+		Nonce:     3,
+		GasPrice:  big.NewInt(80000000000),
+		ChainID:   big.NewInt(12),
+		GasFeeCap: big.NewInt(80000000000),
+		GasTipCap: big.NewInt(80000000000),
+		FeePayer:  feePayer,
+	}
+	parseUnsignedResponse, err := servicer.ConstructionParse(ctx, &types.ConstructionParseRequest{
+		NetworkIdentifier: networkIdentifier,
+		Signed:            false,
+		Transaction:       senderCombineResponse.SignedTransaction,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, ops, parseUnsignedResponse.Operations)
+	assert.Equal(t, forceMarshalMap(t, parseMetadata), parseUnsignedResponse.Metadata)
+
+	// Test Combine with the sender signature in the fee payer round
+	_, err = servicer.ConstructionCombine(ctx, &types.ConstructionCombineRequest{
+		NetworkIdentifier:   networkIdentifier,
+		UnsignedTransaction: senderCombineResponse.SignedTransaction,
+		Signatures:          senderSignatures,
+	})
+	assert.Equal(t, ErrSignatureInvalid.Code, err.Code)
+
+	// Test Combine with the fee payer signature
+	combineResponse, err := servicer.ConstructionCombine(ctx, &types.ConstructionCombineRequest{
+		NetworkIdentifier:   networkIdentifier,
+		UnsignedTransaction: senderCombineResponse.SignedTransaction,
+		Signatures: []*types.Signature{
+			{
+				SigningPayload: payloadsResponse.Payloads[0],
+				PublicKey: &types.PublicKey{
+					Bytes:     crypto.CompressPubkey(&feePayerKey.PublicKey),
+					CurveType: types.Secp256k1,
+				},
+				SignatureType: types.EcdsaRecovery,
+				Bytes:         feePayerSignature,
+			},
+		},
+	})
+	assert.Nil(t, err)
+
+	var feeDelegatedTx metadium.FeeDelegatedTransaction
+	assert.NoError(t, json.Unmarshal([]byte(combineResponse.SignedTransaction), &feeDelegatedTx))
+	assert.Equal(t, common.HexToAddress(feePayer), feeDelegatedTx.FeePayer)
+
+	// Test Parse Signed
+	parseSignedResponse, err := servicer.ConstructionParse(ctx, &types.ConstructionParseRequest{
+		NetworkIdentifier: networkIdentifier,
+		Signed:            true,
+		Transaction:       combineResponse.SignedTransaction,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, &types.ConstructionParseResponse{
+		Operations: ops,
+		AccountIdentifierSigners: []*types.AccountIdentifier{
+			{Address: from},
+			{Address: feePayer},
+		},
+		Metadata: forceMarshalMap(t, parseMetadata),
+	}, parseSignedResponse)
+
+	// Test Hash
+	transactionIdentifier := &types.TransactionIdentifier{
+		Hash: feeDelegatedTx.Hash().Hex(),
+	}
+	hashResponse, err := servicer.ConstructionHash(ctx, &types.ConstructionHashRequest{
+		NetworkIdentifier: networkIdentifier,
+		SignedTransaction: combineResponse.SignedTransaction,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, &types.TransactionIdentifierResponse{
+		TransactionIdentifier: transactionIdentifier,
+	}, hashResponse)
+
+	// Test Submit
+	rawTx, rawErr := feeDelegatedTx.MarshalBinary()
+	assert.NoError(t, rawErr)
+	mockClient.On("SendRawTransaction", ctx, rawTx).Return(nil).Once()
+	submitResponse, err := servicer.ConstructionSubmit(ctx, &types.ConstructionSubmitRequest{
+		NetworkIdentifier: networkIdentifier,
+		SignedTransaction: combineResponse.SignedTransaction,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, &types.TransactionIdentifierResponse{
+		TransactionIdentifier: transactionIdentifier,
+	}, submitResponse)
+
+	// Test Hash (raw)
+	hashResponse, err = servicer.ConstructionHash(ctx, &types.ConstructionHashRequest{
+		NetworkIdentifier: networkIdentifier,
+		SignedTransaction: hexutil.Encode(rawTx),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, &types.TransactionIdentifierResponse{
+		TransactionIdentifier: transactionIdentifier,
+	}, hashResponse)
+
+	// Test Submit (raw)
+	mockClient.On("SendRawTransaction", ctx, rawTx).Return(nil).Once()
+	submitResponse, err = servicer.ConstructionSubmit(ctx, &types.ConstructionSubmitRequest{
+		NetworkIdentifier: networkIdentifier,
+		SignedTransaction: hexutil.Encode(rawTx),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, &types.TransactionIdentifierResponse{
+		TransactionIdentifier: transactionIdentifier,
+	}, submitResponse)
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestConstructionService_FeeTier(t *testing.T) {
+	networkIdentifier = &types.NetworkIdentifier{
+		Network:    metadium.TestnetNetwork,
+		Blockchain: metadium.Blockchain,
+	}
+
+	londonConfig := *params.MetadiumTestnetChainConfig
+	londonConfig.BerlinBlock = big.NewInt(0)
+	londonConfig.LondonBlock = big.NewInt(200)
+	cfg := &configuration.Configuration{
+		Mode:    configuration.Online,
+		Network: networkIdentifier,
+		Params:  &londonConfig,
+	}
+
+	mockClient := &mocks.Client{}
+	servicer := NewConstructionAPIService(cfg, mockClient, NewTransactionTracker(cfg, mockClient))
+	ctx := context.Background()
+
+	from := "0x71562b71999873DB5b286dF957af199Ec94617F7"
+	to := "0x57B414a0332B5CaB885a451c2a28a07d1e9b8a8d"
+	ops := []*types.Operation{
+		{
+			OperationIdentifier: &types.OperationIdentifier{Index: 0},
+			Type:                metadium.CallOpType,
+			Account:             &types.AccountIdentifier{Address: from},
+			Amount:              &types.Amount{Value: "-1000", Currency: metadium.Currency},
+		},
+		{
+			OperationIdentifier: &types.OperationIdentifier{Index: 1},
+			RelatedOperations:   []*types.OperationIdentifier{{Index: 0}},
+			Type:                metadium.CallOpType,
+			Account:             &types.AccountIdentifier{Address: to},
+			Amount:              &types.Amount{Value: "1000", Currency: metadium.Currency},
+		},
+	}
+
+	// Test Preprocess
+	preprocessResponse, err := servicer.ConstructionPreprocess(
+		ctx,
+		&types.ConstructionPreprocessRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations:        ops,
+			Metadata: map[string]interface{}{
+				"fee_tier": "fast",
+			},
+		},
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, "fast", preprocessResponse.Options["fee_tier"])
+
+	// Test Preprocess (invalid tier)
+	_, err = servicer.ConstructionPreprocess(
+		ctx,
+		&types.ConstructionPreprocessRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations:        ops,
+			Metadata: map[string]interface{}{
+				"fee_tier": "instant",
+			},
+		},
+	)
+	assert.Equal(t, ErrInvalidFeeTier.Code, err.Code)
+
+	// Test Metadata (before London, the tier fee is the gas price)
+	history := &metadium.FeeHistory{
+		OldestBlock:  big.NewInt(80),
+		Reward:       [][]*big.Int{{big.NewInt(100000000000)}, {big.NewInt(120000000000)}},
+		GasUsedRatio: []float64{0.2, 0.4},
+	}
+	tierOptions := &options{From: from, FeeTier: fastFeeTier}
+	mockClient.On("SuggestGasPrice", ctx).Return(big.NewInt(80000000000), nil).Once()
+	mockClient.On("PendingNonceAt", ctx, common.HexToAddress(from)).Return(uint64(3), nil).Once()
+	mockClient.On("FeeHistory", ctx, uint64(feeHistoryBlocks), []float64{90}).Return(history, nil).Once()
+	mockClient.On("HeaderByNumber", ctx, (*big.Int)(nil)).Return(
+		&ethTypes.Header{Number: big.NewInt(100)},
+		nil,
+	).Once()
+	metadataResponse, err := servicer.ConstructionMetadata(ctx, &types.ConstructionMetadataRequest{
+		NetworkIdentifier: networkIdentifier,
+		Options:           forceMarshalMap(t, tierOptions),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, &types.ConstructionMetadataResponse{
+		Metadata: forceMarshalMap(t, &metadata{
+			GasPrice: big.NewInt(120000000000),
+			Nonce:    3,
+			GasLimit: 21000,
+		}),
+		SuggestedFee: []*types.Amount{
+			{
+				Value:    "2520000000000000",
+				Currency: metadium.Currency,
+			},
+		},
+	}, metadataResponse)
+
+	// Test Metadata (after London, the tier fee is the priority fee)
+	history = &metadium.FeeHistory{
+		OldestBlock:  big.NewInt(280),
+		Reward:       [][]*big.Int{{big.NewInt(2000000000)}, {big.NewInt(0)}},
+		BaseFee:      []*big.Int{big.NewInt(80000000000), big.NewInt(80000000000), big.NewInt(80000000000)},
+		GasUsedRatio: []float64{0.2, 0},
+	}
+	mockClient.On("SuggestGasPrice", ctx).Return(big.NewInt(80000000000), nil).Once()
+	mockClient.On("PendingNonceAt", ctx, common.HexToAddress(from)).Return(uint64(3), nil).Once()
+	mockClient.On("FeeHistory", ctx, uint64(feeHistoryBlocks), []float64{90}).Return(history, nil).Once()
+	mockClient.On("HeaderByNumber", ctx, (*big.Int)(nil)).Return(
+		&ethTypes.Header{Number: big.NewInt(300), BaseFee: big.NewInt(80000000000)},
+		nil,
+	).Once()
+	metadataResponse, err = servicer.ConstructionMetadata(ctx, &types.ConstructionMetadataRequest{
+		NetworkIdentifier: networkIdentifier,
+		Options:           forceMarshalMap(t, tierOptions),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, &types.ConstructionMetadataResponse{
+		Metadata: forceMarshalMap(t, &metadata{
+			GasPrice:  big.NewInt(80000000000),
+			Nonce:     3,
+			GasLimit:  21000,
+			BaseFee:   big.NewInt(80000000000),
+			GasFeeCap: big.NewInt(162000000000),
+			GasTipCap: big.NewInt(2000000000),
+		}),
+		SuggestedFee: []*types.Amount{
+			{
+				Value:    "1722000000000000",
+				Currency: metadium.Currency,
+			},
+		},
+	}, metadataResponse)
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestConstructionService_NonceReservation(t *testing.T) {
+	networkIdentifier = &types.NetworkIdentifier{
+		Network:    metadium.TestnetNetwork,
+		Blockchain: metadium.Blockchain,
+	}
+
+	cfg := &configuration.Configuration{
+		Mode:     configuration.Online,
+		Network:  networkIdentifier,
+		Params:   params.MetadiumTestnetChainConfig,
+		NonceTTL: time.Minute,
+	}
+
+	mockClient := &mocks.Client{}
+	servicer := NewConstructionAPIService(cfg, mockClient, NewTransactionTracker(cfg, mockClient))
+	ctx := context.Background()
+
+	privateKey, keyErr := crypto.HexToECDSA(
+		"b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291",
+	)
+	assert.NoError(t, keyErr)
+
+	from := "0x71562b71999873DB5b286dF957af199Ec94617F7"
+	to := "0x57B414a0332B5CaB885a451c2a28a07d1e9b8a8d"
+	toAddr := common.HexToAddress(to)
+	transferOptions := &options{
+		From:  from,
+		To:    to,
+		Value: big.NewInt(1000),
+	}
+	metadataNonce := func() uint64 {
+		mockClient.On("SuggestGasPrice", ctx).Return(big.NewInt(80000000000), nil).Once()
+		mockClient.On("PendingNonceAt", ctx, common.HexToAddress(from)).Return(uint64(3), nil).Once()
+		mockClient.On(
+			"EstimateGas",
+			ctx,
+			ethereum.CallMsg{
+				From:  common.HexToAddress(from),
+				To:    &toAddr,
+				Value: big.NewInt(1000),
+			},
+		).Return(uint64(21000), nil).Once()
+		mockClient.On("HeaderByNumber", ctx, (*big.Int)(nil)).Return(
+			&ethTypes.Header{Number: big.NewInt(100)},
+			nil,
+		).Once()
+		metadataResponse, err := servicer.ConstructionMetadata(ctx, &types.ConstructionMetadataRequest{
+			NetworkIdentifier: networkIdentifier,
+			Options:           forceMarshalMap(t, transferOptions),
+		})
+		assert.Nil(t, err)
+
+		var transferMetadata metadata
+		assert.NoError(t, unmarshalJSONMap(metadataResponse.Metadata, &transferMetadata))
+		return transferMetadata.Nonce
+	}
+
+	// Test Metadata (concurrent constructions)
+	assert.Equal(t, uint64(3), metadataNonce())
+	assert.Equal(t, uint64(4), metadataNonce())
+
+	// Test Submit (failed broadcast releases the nonce)
+	signedTx, signErr := ethTypes.SignTx(
+		ethTypes.NewTransaction(3, toAddr, big.NewInt(1000), 21000, big.NewInt(80000000000), nil),
+		ethTypes.NewLondonSigner(params.MetadiumTestnetChainConfig.ChainID),
+		privateKey,
+	)
+	assert.NoError(t, signErr)
+	signedTxJSON, marshalErr := signedTx.MarshalJSON()
+	assert.NoError(t, marshalErr)
+	mockClient.On("SendTransaction", ctx, mock.Anything).Return(errors.New("insufficient funds")).Once()
+	_, err := servicer.ConstructionSubmit(ctx, &types.ConstructionSubmitRequest{
+		NetworkIdentifier: networkIdentifier,
+		SignedTransaction: string(signedTxJSON),
+	})
+	assert.Equal(t, ErrInsufficientFunds.Code, err.Code)
+	assert.Equal(t, uint64(3), metadataNonce())
+	assert.Equal(t, uint64(5), metadataNonce())
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestConstructionService_Caps(t *testing.T) {
+	networkIdentifier = &types.NetworkIdentifier{
+		Network:    metadium.TestnetNetwork,
+		Blockchain: metadium.Blockchain,
+	}
+
+	cfg := &configuration.Configuration{
+		Mode:        configuration.Online,
+		Network:     networkIdentifier,
+		Params:      params.MetadiumTestnetChainConfig,
+		MaxGasPrice: big.NewInt(100000000000),
+		MaxFee:      big.NewInt(10000000000000000),
+		MaxValue:    big.NewInt(1000000),
+	}
+
+	mockClient := &mocks.Client{}
+	servicer := NewConstructionAPIService(cfg, mockClient, NewTransactionTracker(cfg, mockClient))
+	ctx := context.Background()
+
+	privateKey, keyErr := crypto.HexToECDSA(
+		"b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291",
+	)
+	assert.NoError(t, keyErr)
+
+	from := "0x71562b71999873DB5b286dF957af199Ec94617F7"
+	to := "0x57B414a0332B5CaB885a451c2a28a07d1e9b8a8d"
+	transferOps := func(amount int64) []*types.Operation {
+		return []*types.Operation{
+			{
+				OperationIdentifier: &types.OperationIdentifier{Index: 0},
+				Type:                metadium.CallOpType,
+				Account:             &types.AccountIdentifier{Address: from},
+				Amount:              &types.Amount{Value: big.NewInt(-amount).String(), Currency: metadium.Currency},
+			},
+			{
+				OperationIdentifier: &types.OperationIdentifier{Index: 1},
+				RelatedOperations:   []*types.OperationIdentifier{{Index: 0}},
+				Type:                metadium.CallOpType,
+				Account:             &types.AccountIdentifier{Address: to},
+				Amount:              &types.Amount{Value: big.NewInt(amount).String(), Currency: metadium.Currency},
+			},
+		}
+	}
+
+	tests := map[string]struct {
+		amount   int64
+		metadata *metadata
+		err      *types.Error
+	}{
+		"within caps": {
+			amount:   1000,
+			metadata: &metadata{GasPrice: big.NewInt(80000000000), GasLimit: 21000},
+		},
+		"gas price too high": {
+			amount:   1000,
+			metadata: &metadata{GasPrice: big.NewInt(120000000000), GasLimit: 21000},
+			err:      ErrGasPriceTooHigh,
+		},
+		"max fee per gas too high": {
+			amount: 1000,
+			metadata: &metadata{
+				GasPrice:  big.NewInt(80000000000),
+				GasLimit:  21000,
+				GasFeeCap: big.NewInt(161000000000),
+				GasTipCap: big.NewInt(1000000000),
+			},
+			err: ErrGasPriceTooHigh,
+		},
+		"fee too high": {
+			amount:   1000,
+			metadata: &metadata{GasPrice: big.NewInt(80000000000), GasLimit: 200000},
+			err:      ErrFeeTooHigh,
+		},
+		"value too high": {
+			amount:   2000000,
+			metadata: &metadata{GasPrice: big.NewInt(80000000000), GasLimit: 21000},
+			err:      ErrValueTooHigh,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Test Payloads
+			payloadsResponse, err := servicer.ConstructionPayloads(ctx, &types.ConstructionPayloadsRequest{
+				NetworkIdentifier: networkIdentifier,
+				Operations:        transferOps(test.amount),
+				Metadata:          forceMarshalMap(t, test.metadata),
+			})
+			if test.err != nil {
+				assert.Nil(t, payloadsResponse)
+				assert.Equal(t, test.err.Code, err.Code)
+			} else {
+				assert.Nil(t, err)
+			}
+
+			// Test Combine
+			unsignedTx := &transaction{
+				From:      from,
+				To:        to,
+				Value:     big.NewInt(test.amount),
+				Data:      []byte{},
+				Nonce:     3,
+				GasPrice:  test.metadata.GasPrice,
+				GasLimit:  test.metadata.GasLimit,
+				ChainID:   params.MetadiumTestnetChainConfig.ChainID,
+				GasFeeCap: test.metadata.GasFeeCap,
+				GasTipCap: test.metadata.GasTipCap,
+			}
+			if unsignedTx.isDynamicFee() {
+				unsignedTx.GasPrice = unsignedTx.GasFeeCap
+			}
+			unsignedTxJSON, marshalErr := json.Marshal(unsignedTx)
+			assert.NoError(t, marshalErr)
+
+			ethTx := newEthTransaction(unsignedTx)
+			signer := ethTypes.NewLondonSigner(unsignedTx.ChainID)
+			signature, signErr := crypto.Sign(signer.Hash(ethTx).Bytes(), privateKey)
+			assert.NoError(t, signErr)
+			combineResponse, err := servicer.ConstructionCombine(ctx, &types.ConstructionCombineRequest{
+				NetworkIdentifier:   networkIdentifier,
+				UnsignedTransaction: string(unsignedTxJSON),
+				Signatures: []*types.Signature{
+					{
+						SigningPayload: &types.SigningPayload{
+							AccountIdentifier: &types.AccountIdentifier{Address: from},
+							Bytes:             signer.Hash(ethTx).Bytes(),
+							SignatureType:     types.EcdsaRecovery,
+						},
+						PublicKey: &types.PublicKey{
+							Bytes:     crypto.CompressPubkey(&privateKey.PublicKey),
+							CurveType: types.Secp256k1,
+						},
+						SignatureType: types.EcdsaRecovery,
+						Bytes:         signature,
+					},
+				},
+			})
+			if test.err != nil {
+				assert.Nil(t, combineResponse)
+				assert.Equal(t, test.err.Code, err.Code)
+			} else {
+				assert.Nil(t, err)
+			}
+
+			// Test Submit
+			signedTx, signErr := ethTx.WithSignature(signer, signature)
+			assert.NoError(t, signErr)
+			signedTxJSON, marshalErr := signedTx.MarshalJSON()
+			assert.NoError(t, marshalErr)
+			if test.err == nil {
+				mockClient.On("SendTransaction", ctx, mock.Anything).Return(nil).Once()
+			}
+			submitResponse, err := servicer.ConstructionSubmit(ctx, &types.ConstructionSubmitRequest{
+				NetworkIdentifier: networkIdentifier,
+				SignedTransaction: string(signedTxJSON),
+			})
+			if test.err != nil {
+				assert.Nil(t, submitResponse)
+				assert.Equal(t, test.err.Code, err.Code)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestConstructionService_RawTransaction(t *testing.T) {
+	networkIdentifier = &types.NetworkIdentifier{
+		Network:    metadium.TestnetNetwork,
+		Blockchain: metadium.Blockchain,
+	}
+
+	cfg := &configuration.Configuration{
+		Mode:    configuration.Online,
+		Network: networkIdentifier,
+		Params:  params.MetadiumTestnetChainConfig,
+	}
+
+	mockClient := &mocks.Client{}
+	servicer := NewConstructionAPIService(cfg, mockClient, NewTransactionTracker(cfg, mockClient))
+	ctx := context.Background()
+
+	privateKey, keyErr := crypto.HexToECDSA(
+		"b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291",
+	)
+	assert.NoError(t, keyErr)
+
+	from := "0x71562b71999873DB5b286dF957af199Ec94617F7"
+	to := "0x57B414a0332B5CaB885a451c2a28a07d1e9b8a8d"
+	ops := []*types.Operation{
+		{
+			OperationIdentifier: &types.OperationIdentifier{Index: 0},
+			Type:                metadium.CallOpType,
+			Account:             &types.AccountIdentifier{Address: from},
+			Amount:              &types.Amount{Value: "-1000", Currency: metadium.Currency},
+		},
+		{
+			OperationIdentifier: &types.OperationIdentifier{Index: 1},
+			RelatedOperations:   []*types.OperationIdentifier{{Index: 0}},
+			Type:                metadium.CallOpType,
+			Account:             &types.AccountIdentifier{Address: to},
+			Amount:              &types.Amount{Value: "1000", Currency: metadium.Currency},
+		},
+	}
+
+	// Test Preprocess
+	preprocessResponse, err := servicer.ConstructionPreprocess(
+		ctx,
+		&types.ConstructionPreprocessRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations:        ops,
+			Metadata: map[string]interface{}{
+				"raw_transaction": true,
+			},
+		},
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, true, preprocessResponse.Options["raw_transaction"])
+
+	// Test Payloads
+	payloadsResponse, err := servicer.ConstructionPayloads(ctx, &types.ConstructionPayloadsRequest{
+		NetworkIdentifier: networkIdentifier,
+		Operations:        ops,
+		Metadata: forceMarshalMap(t, &metadata{
+			GasPrice:       big.NewInt(80000000000),
+			Nonce:          3,
+			GasLimit:       21000,
+			RawTransaction: true,
+		}),
+	})
+	assert.Nil(t, err)
+
+	// Test Combine
+	signature, signErr := crypto.Sign(payloadsResponse.Payloads[0].Bytes, privateKey)
+	assert.NoError(t, signErr)
+	combineResponse, err := servicer.ConstructionCombine(ctx, &types.ConstructionCombineRequest{
+		NetworkIdentifier:   networkIdentifier,
+		UnsignedTransaction: payloadsResponse.UnsignedTransaction,
+		Signatures: []*types.Signature{
+			{
+				SigningPayload: payloadsResponse.Payloads[0],
+				PublicKey: &types.PublicKey{
+					Bytes:     crypto.CompressPubkey(&privateKey.PublicKey),
+					CurveType: types.Secp256k1,
+				},
+				SignatureType: types.EcdsaRecovery,
+				Bytes:         signature,
+			},
+		},
+	})
+	assert.Nil(t, err)
+
+	signer := ethTypes.NewLondonSigner(params.MetadiumTestnetChainConfig.ChainID)
+	legacyTx, signErr := ethTypes.SignTx(
+		ethTypes.NewTransaction(3, common.HexToAddress(to), big.NewInt(1000), 21000, big.NewInt(80000000000), []byte{}),
+		signer,
+		privateKey,
+	)
+	assert.NoError(t, signErr)
+	legacyRaw, marshalErr := legacyTx.MarshalBinary()
+	assert.NoError(t, marshalErr)
+	assert.Equal(t, hexutil.Encode(legacyRaw), combineResponse.SignedTransaction)
+
+	toAddr := common.HexToAddress(to)
+	dynamicFeeTx, signErr := ethTypes.SignNewTx(privateKey, signer, &ethTypes.DynamicFeeTx{
+		ChainID:   params.MetadiumTestnetChainConfig.ChainID,
+		Nonce:     4,
+		GasTipCap: big.NewInt(1000000000),
+		GasFeeCap: big.NewInt(161000000000),
+		Gas:       21000,
+		To:        &toAddr,
+		Value:     big.NewInt(1000),
+	})
+	assert.NoError(t, signErr)
+	dynamicFeeRaw, marshalErr := dynamicFeeTx.MarshalBinary()
+	assert.NoError(t, marshalErr)
+
+	tests := map[string]struct {
+		signedTx *ethTypes.Transaction
+		raw      string
+		metadata *parseMetadata
+	}{
+		"legacy": {
+			signedTx: legacyTx,
+			raw:      combineResponse.SignedTransaction,
+			metadata: &parseMetadata{
+				Nonce:    3,
+				GasPrice: big.NewInt(80000000000),
+				ChainID:  params.MetadiumTestnetChainConfig.ChainID,
+			},
+		},
+		"dynamic fee": {
+			signedTx: dynamicFeeTx,
+			raw:      hexutil.Encode(dynamicFeeRaw),
+			metadata: &parseMetadata{
+				Nonce:     4,
+				GasPrice:  big.NewInt(161000000000),
+				ChainID:   params.MetadiumTestnetChainConfig.ChainID,
+				GasFeeCap: big.NewInt(161000000000),
+				GasTipCap: big.NewInt(1000000000),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Test Parse Signed
+			parseSignedResponse, err := servicer.ConstructionParse(ctx, &types.ConstructionParseRequest{
+				NetworkIdentifier: networkIdentifier,
+				Signed:            true,
+				Transaction:       test.raw,
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, &types.ConstructionParseResponse{
+				Operations: []*types.Operation{
+					{
+						OperationIdentifier: ops[0].OperationIdentifier,
+						Type:                ops[0].Type,
+						Account:             ops[0].Account,
+						Amount:              ops[0].Amount,
+					},
+					ops[1],
+				},
+				AccountIdentifierSigners: []*types.AccountIdentifier{{Address: from}},
+				Metadata:                 forceMarshalMap(t, test.metadata),
+			}, parseSignedResponse)
+
+			// Test Hash
+			transactionIdentifier := &types.TransactionIdentifier{
+				Hash: test.signedTx.Hash().Hex(),
+			}
+			hashResponse, err := servicer.ConstructionHash(ctx, &types.ConstructionHashRequest{
+				NetworkIdentifier: networkIdentifier,
+				SignedTransaction: test.raw,
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, &types.TransactionIdentifierResponse{
+				TransactionIdentifier: transactionIdentifier,
+			}, hashResponse)
+
+			// Test Submit
+			mockClient.On(
+				"SendTransaction",
+				ctx,
+				mock.MatchedBy(func(tx *ethTypes.Transaction) bool {
+					return tx.Hash() == test.signedTx.Hash()
+				}),
+			).Return(nil).Once()
+			submitResponse, err := servicer.ConstructionSubmit(ctx, &types.ConstructionSubmitRequest{
+				NetworkIdentifier: networkIdentifier,
+				SignedTransaction: test.raw,
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, &types.TransactionIdentifierResponse{
+				TransactionIdentifier: transactionIdentifier,
+			}, submitResponse)
+		})
+	}
+
+	// Raw transactions carry no currency or method signature, so
+	// ERC-20 transfers and method calls cannot be returned raw.
+	tokenAddress := "0x2d74530C0C196De44d3906822053bf336F18a16e"
+	currency := &types.Currency{
+		Symbol:   "TKN",
+		Decimals: 18,
+		Metadata: map[string]interface{}{
+			metadium.ContractAddressKey: tokenAddress,
+		},
+	}
+	tokenOps := []*types.Operation{
+		{
+			OperationIdentifier: &types.OperationIdentifier{Index: 0},
+			Type:                metadium.ERC20TransferOpType,
+			Account:             &types.AccountIdentifier{Address: from},
+			Amount:              &types.Amount{Value: "-1000", Currency: currency},
+		},
+		{
+			OperationIdentifier: &types.OperationIdentifier{Index: 1},
+			RelatedOperations:   []*types.OperationIdentifier{{Index: 0}},
+			Type:                metadium.ERC20TransferOpType,
+			Account:             &types.AccountIdentifier{Address: to},
+			Amount:              &types.Amount{Value: "1000", Currency: currency},
+		},
+	}
+	transferData := metadium.ERC20TransferData(common.HexToAddress(to), big.NewInt(1000))
+
+	// Test Preprocess (raw ERC-20 transfer)
+	_, err = servicer.ConstructionPreprocess(
+		ctx,
+		&types.ConstructionPreprocessRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations:        tokenOps,
+			Metadata: map[string]interface{}{
+				"raw_transaction": true,
+			},
+		},
+	)
+	assert.Equal(t, ErrUnclearIntent.Code, err.Code)
+
+	// Test Preprocess (raw contract call)
+	_, err = servicer.ConstructionPreprocess(
+		ctx,
+		&types.ConstructionPreprocessRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations:        ops,
+			Metadata: map[string]interface{}{
+				"raw_transaction":  true,
+				"method_signature": "deposit(uint256)",
+				"method_args":      []string{"1"},
+			},
+		},
+	)
+	assert.Equal(t, ErrUnclearIntent.Code, err.Code)
+
+	// Test Payloads (raw ERC-20 transfer)
+	_, err = servicer.ConstructionPayloads(ctx, &types.ConstructionPayloadsRequest{
+		NetworkIdentifier: networkIdentifier,
+		Operations:        tokenOps,
+		Metadata: forceMarshalMap(t, &metadata{
+			GasPrice:       big.NewInt(80000000000),
+			Nonce:          3,
+			GasLimit:       60000,
+			Data:           transferData,
+			RawTransaction: true,
+		}),
+	})
+	assert.Equal(t, ErrUnclearIntent.Code, err.Code)
+
+	// Test Parse Signed (raw ERC-20 transfer signed elsewhere)
+	tokenTx, signErr := ethTypes.SignNewTx(
+		privateKey,
+		ethTypes.NewLondonSigner(params.MetadiumTestnetChainConfig.ChainID),
+		&ethTypes.LegacyTx{
+			Nonce:    3,
+			To:       recipient(tokenAddress),
+			Gas:      60000,
+			GasPrice: big.NewInt(80000000000),
+			Data:     transferData,
+		},
+	)
+	assert.NoError(t, signErr)
+	rawTokenTx, marshalErr := tokenTx.MarshalBinary()
+	assert.NoError(t, marshalErr)
+	parseTokenResponse, err := servicer.ConstructionParse(ctx, &types.ConstructionParseRequest{
+		NetworkIdentifier: networkIdentifier,
+		Signed:            true,
+		Transaction:       hexutil.Encode(rawTokenTx),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, &types.ConstructionParseResponse{
+		Operations: []*types.Operation{
+			{
+				Type:                metadium.CallOpType,
+				OperationIdentifier: &types.OperationIdentifier{Index: 0},
+				Account:             &types.AccountIdentifier{Address: from},
+				Amount:              &types.Amount{Value: "0", Currency: metadium.Currency},
+			},
+			{
+				Type:                metadium.CallOpType,
+				OperationIdentifier: &types.OperationIdentifier{Index: 1},
+				RelatedOperations:   []*types.OperationIdentifier{{Index: 0}},
+				Account:             &types.AccountIdentifier{Address: tokenAddress},
+				Amount:              &types.Amount{Value: "0", Currency: metadium.Currency},
+			},
+		},
+		AccountIdentifierSigners: []*types.AccountIdentifier{{Address: from}},
+		Metadata: forceMarshalMap(t, &parseMetadata{
+			Nonce:    3,
+			GasPrice: big.NewInt(80000000000),
+			ChainID:  big.NewInt(12),
+			Data:     transferData,
+		}),
+	}, parseTokenResponse)
+
+	// Test Hash (invalid hex)
+	_, err = servicer.ConstructionHash(ctx, &types.ConstructionHashRequest{
+		NetworkIdentifier: networkIdentifier,
+		SignedTransaction: "0xzz",
+	})
+	assert.Equal(t, ErrUnableToParseIntermediateResult.Code, err.Code)
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestConstructionService_ExtendedPublicKey(t *testing.T) {
+	networkIdentifier = &types.NetworkIdentifier{
+		Network:    metadium.TestnetNetwork,
+		Blockchain: metadium.Blockchain,
+	}
+
+	cfg := &configuration.Configuration{
+		Mode:    configuration.Offline,
+		Network: networkIdentifier,
+		Params:  params.MetadiumTestnetChainConfig,
+	}
+
+	servicer := NewConstructionAPIService(cfg, nil, nil)
+	ctx := context.Background()
+
+	// BIP-32 test vector 1, chain m/0H/1/2H
+	xpub := "xpub6D4BDPcP2GT577Vvch3R8wDkScZWzQzMMUm3PWbmWvVJrZwQY4VUNgqFJPMM3No2dFDFGTsxxpG5uJh7n7epu4trkrX7x7DogT5Uv6fcLW5" // nolint
+	publicKey := &types.PublicKey{
+		Bytes:     forceHexDecode(t, "0357bfe1e341d01c69fe5654309956cbea516822fba8a601743a012a7896ee8dc2"),
+		CurveType: types.Secp256k1,
+	}
+	xpubKey, keyErr := crypto.DecompressPubkey(publicKey.Bytes)
+	assert.NoError(t, keyErr)
+
+	tests := map[string]struct {
+		publicKey *types.PublicKey
+		metadata  map[string]interface{}
+
+		expected    *types.ConstructionDeriveResponse
+		expectedErr *types.Error
+	}{
+		"derived key": {
+			publicKey: publicKey,
+			metadata: map[string]interface{}{
+				"xpub":            xpub,
+				"derivation_path": "m/0'/1/2'/2/1000000000",
+			},
+			expected: &types.ConstructionDeriveResponse{
+				AccountIdentifier: &types.AccountIdentifier{
+					Address: "0x73659c60270d326c06Ac204F1A9C63f889a3D14B",
+				},
+				Metadata: map[string]interface{}{
+					"public_key": map[string]interface{}{
+						"hex_bytes":  "022a471424da5e657499d1ff51cb43c47481a03b1e77f951fe64cec9f5a48f7011",
+						"curve_type": "secp256k1",
+					},
+				},
+			},
+		},
+		"xpub key": {
+			publicKey: publicKey,
+			metadata: map[string]interface{}{
+				"xpub":            xpub,
+				"derivation_path": "m/0'/1/2'",
+			},
+			expected: &types.ConstructionDeriveResponse{
+				AccountIdentifier: &types.AccountIdentifier{
+					Address: crypto.PubkeyToAddress(*xpubKey).Hex(),
+				},
+				Metadata: map[string]interface{}{
+					"public_key": map[string]interface{}{
+						"hex_bytes":  "0357bfe1e341d01c69fe5654309956cbea516822fba8a601743a012a7896ee8dc2",
+						"curve_type": "secp256k1",
+					},
+				},
+			},
+		},
+		"hardened path": {
+			publicKey: publicKey,
+			metadata: map[string]interface{}{
+				"xpub":            xpub,
+				"derivation_path": "m/0'/1/2'/2'",
+			},
+			expectedErr: ErrInvalidDerivationPath,
+		},
+		"path not leading to xpub": {
+			publicKey: publicKey,
+			metadata: map[string]interface{}{
+				"xpub":            xpub,
+				"derivation_path": "m/44'/916'/0'/0/0",
+			},
+			expectedErr: ErrInvalidDerivationPath,
+		},
+		"relative path": {
+			publicKey: publicKey,
+			metadata: map[string]interface{}{
+				"xpub":            xpub,
+				"derivation_path": "0/0",
+			},
+			expectedErr: ErrInvalidDerivationPath,
+		},
+		"path without xpub": {
+			publicKey: publicKey,
+			metadata: map[string]interface{}{
+				"derivation_path": "m/0'/1/2'/2",
+			},
+			expectedErr: ErrInvalidDerivationPath,
+		},
+		"invalid checksum": {
+			publicKey: publicKey,
+			metadata: map[string]interface{}{
+				"xpub":            xpub[:len(xpub)-1] + "6",
+				"derivation_path": "m/0'/1/2'/2",
+			},
+			expectedErr: ErrInvalidExtendedPublicKey,
+		},
+		"public key not of xpub": {
+			publicKey: &types.PublicKey{
+				Bytes:     forceHexDecode(t, "036d9038945ff8f4669201ba1e806c9a46a5034a578e4d52c03152198538039294"),
+				CurveType: types.Secp256k1,
+			},
+			metadata: map[string]interface{}{
+				"xpub":            xpub,
+				"derivation_path": "m/0'/1/2'/2",
+			},
+			expectedErr: ErrInvalidExtendedPublicKey,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			deriveResponse, err := servicer.ConstructionDerive(ctx, &types.ConstructionDeriveRequest{
+				NetworkIdentifier: networkIdentifier,
+				PublicKey:         test.publicKey,
+				Metadata:          test.metadata,
+			})
+			if test.expectedErr != nil {
+				assert.Nil(t, deriveResponse)
+				assert.Equal(t, test.expectedErr.Code, err.Code)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected, deriveResponse)
+		})
+	}
+}
+
+func TestConstructionService_VerifySigner(t *testing.T) {
+	networkIdentifier = &types.NetworkIdentifier{
+		Network:    metadium.TestnetNetwork,
+		Blockchain: metadium.Blockchain,
+	}
+
+	cfg := &configuration.Configuration{
+		Mode:    configuration.Online,
+		Network: networkIdentifier,
+		Params:  params.MetadiumTestnetChainConfig,
+	}
+
+	mockClient := &mocks.Client{}
+	servicer := NewConstructionAPIService(cfg, mockClient, NewTransactionTracker(cfg, mockClient))
+	ctx := context.Background()
+
+	privateKey, keyErr := crypto.HexToECDSA(
+		"b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291",
+	)
+	assert.NoError(t, keyErr)
+	otherKey, keyErr := crypto.GenerateKey()
+	assert.NoError(t, keyErr)
+
+	from := "0x71562b71999873DB5b286dF957af199Ec94617F7"
+	to := "0x57B414a0332B5CaB885a451c2a28a07d1e9b8a8d"
+	unsignedTransaction := func(chainID *big.Int) (*transaction, string) {
+		unsignedTx := &transaction{
+			From:     from,
+			To:       to,
+			Value:    big.NewInt(1000),
+			Data:     []byte{},
+			Nonce:    3,
+			GasPrice: big.NewInt(80000000000),
+			GasLimit: 21000,
+			ChainID:  chainID,
+		}
+		unsignedTxJSON, err := json.Marshal(unsignedTx)
+		assert.NoError(t, err)
+
+		return unsignedTx, string(unsignedTxJSON)
+	}
+	sign := func(unsignedTx *transaction, key *ecdsa.PrivateKey, publicKey *ecdsa.PublicKey) *types.Signature {
+		hash := ethTypes.NewLondonSigner(unsignedTx.ChainID).Hash(newEthTransaction(unsignedTx))
+		signature, err := crypto.Sign(hash.Bytes(), key)
+		assert.NoError(t, err)
+
+		return &types.Signature{
+			SigningPayload: &types.SigningPayload{
+				AccountIdentifier: &types.AccountIdentifier{Address: from},
+				Bytes:             hash.Bytes(),
+				SignatureType:     types.EcdsaRecovery,
+			},
+			PublicKey: &types.PublicKey{
+				Bytes:     crypto.CompressPubkey(publicKey),
+				CurveType: types.Secp256k1,
+			},
+			SignatureType: types.EcdsaRecovery,
+			Bytes:         signature,
+		}
+	}
+
+	unsignedTx, unsignedTxJSON := unsignedTransaction(params.MetadiumTestnetChainConfig.ChainID)
+	tests := map[string]struct {
+		signature *types.Signature
+		err       *types.Error
+	}{
+		"valid signature": {
+			signature: sign(unsignedTx, privateKey, &privateKey.PublicKey),
+		},
+		"signed by another account": {
+			signature: sign(unsignedTx, otherKey, &otherKey.PublicKey),
+			err:       ErrSignatureInvalid,
+		},
+		"public key of another account": {
+			signature: sign(unsignedTx, privateKey, &otherKey.PublicKey),
+			err:       ErrSignatureInvalid,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			combineResponse, err := servicer.ConstructionCombine(ctx, &types.ConstructionCombineRequest{
+				NetworkIdentifier:   networkIdentifier,
+				UnsignedTransaction: unsignedTxJSON,
+				Signatures:          []*types.Signature{test.signature},
+			})
+			if test.err != nil {
+				assert.Nil(t, combineResponse)
+				assert.Equal(t, test.err.Code, err.Code)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+
+	// Test Combine (no signatures)
+	_, err := servicer.ConstructionCombine(ctx, &types.ConstructionCombineRequest{
+		NetworkIdentifier:   networkIdentifier,
+		UnsignedTransaction: unsignedTxJSON,
+	})
+	assert.Equal(t, ErrSignatureInvalid.Code, err.Code)
+
+	// Test Combine (too many signatures)
+	signature := sign(unsignedTx, privateKey, &privateKey.PublicKey)
+	_, err = servicer.ConstructionCombine(ctx, &types.ConstructionCombineRequest{
+		NetworkIdentifier:   networkIdentifier,
+		UnsignedTransaction: unsignedTxJSON,
+		Signatures:          []*types.Signature{signature, signature},
+	})
+	assert.Equal(t, ErrSignatureInvalid.Code, err.Code)
+
+	// Test Combine (chain ID of another network)
+	mainnetTx, mainnetTxJSON := unsignedTransaction(params.MetadiumMainnetChainConfig.ChainID)
+	_, err = servicer.ConstructionCombine(ctx, &types.ConstructionCombineRequest{
+		NetworkIdentifier:   networkIdentifier,
+		UnsignedTransaction: mainnetTxJSON,
+		Signatures:          []*types.Signature{sign(mainnetTx, privateKey, &privateKey.PublicKey)},
+	})
+	assert.Equal(t, ErrInvalidChainID.Code, err.Code)
+
+	// Test Parse Unsigned (chain ID of another network)
+	_, err = servicer.ConstructionParse(ctx, &types.ConstructionParseRequest{
+		NetworkIdentifier: networkIdentifier,
+		Signed:            false,
+		Transaction:       mainnetTxJSON,
+	})
+	assert.Equal(t, ErrInvalidChainID.Code, err.Code)
+
+	// Test Parse Signed and Submit (chain ID of another network)
+	signedTx, signErr := ethTypes.SignTx(
+		newEthTransaction(mainnetTx),
+		ethTypes.NewLondonSigner(mainnetTx.ChainID),
+		privateKey,
+	)
+	assert.NoError(t, signErr)
+	signedTxJSON, marshalErr := signedTx.MarshalJSON()
+	assert.NoError(t, marshalErr)
+	_, err = servicer.ConstructionParse(ctx, &types.ConstructionParseRequest{
+		NetworkIdentifier: networkIdentifier,
+		Signed:            true,
+		Transaction:       string(signedTxJSON),
+	})
+	assert.Equal(t, ErrInvalidChainID.Code, err.Code)
+
+	_, err = servicer.ConstructionSubmit(ctx, &types.ConstructionSubmitRequest{
+		NetworkIdentifier: networkIdentifier,
+		SignedTransaction: string(signedTxJSON),
+	})
+	assert.Equal(t, ErrInvalidChainID.Code, err.Code)
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestConstructionService_EcdsaSignature(t *testing.T) {
+	networkIdentifier = &types.NetworkIdentifier{
+		Network:    metadium.TestnetNetwork,
+		Blockchain: metadium.Blockchain,
+	}
+
+	cfg := &configuration.Configuration{
+		Mode:    configuration.Online,
+		Network: networkIdentifier,
+		Params:  params.MetadiumTestnetChainConfig,
+	}
+
+	mockClient := &mocks.Client{}
+	servicer := NewConstructionAPIService(cfg, mockClient, NewTransactionTracker(cfg, mockClient))
+	ctx := context.Background()
+
+	privateKey, keyErr := crypto.HexToECDSA(
+		"b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291",
+	)
+	assert.NoError(t, keyErr)
+	otherKey, keyErr := crypto.GenerateKey()
+	assert.NoError(t, keyErr)
+
+	from := "0x71562b71999873DB5b286dF957af199Ec94617F7"
+	to := "0x57B414a0332B5CaB885a451c2a28a07d1e9b8a8d"
+	ops := []*types.Operation{
+		{
+			OperationIdentifier: &types.OperationIdentifier{Index: 0},
+			Type:                metadium.CallOpType,
+			Account:             &types.AccountIdentifier{Address: from},
+			Amount:              &types.Amount{Value: "-1000", Currency: metadium.Currency},
+		},
+		{
+			OperationIdentifier: &types.OperationIdentifier{Index: 1},
+			RelatedOperations:   []*types.OperationIdentifier{{Index: 0}},
+			Type:                metadium.CallOpType,
+			Account:             &types.AccountIdentifier{Address: to},
+			Amount:              &types.Amount{Value: "1000", Currency: metadium.Currency},
+		},
+	}
+
+	// Test Preprocess
+	preprocessResponse, err := servicer.ConstructionPreprocess(
+		ctx,
+		&types.ConstructionPreprocessRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations:        ops,
+			Metadata: map[string]interface{}{
+				"signature_type": "ecdsa",
+			},
+		},
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, "ecdsa", preprocessResponse.Options["signature_type"])
+
+	// Test Preprocess (unsupported signature type)
+	preprocessResponse, err = servicer.ConstructionPreprocess(
+		ctx,
+		&types.ConstructionPreprocessRequest{
+			NetworkIdentifier: networkIdentifier,
+			Operations:        ops,
+			Metadata: map[string]interface{}{
+				"signature_type": "schnorr_1",
+			},
+		},
+	)
+	assert.Nil(t, preprocessResponse)
+	assert.Equal(t, ErrUnsupportedSignatureType.Code, err.Code)
+
+	// Test Payloads
+	payloadsResponse, err := servicer.ConstructionPayloads(ctx, &types.ConstructionPayloadsRequest{
+		NetworkIdentifier: networkIdentifier,
+		Operations:        ops,
+		Metadata: forceMarshalMap(t, &metadata{
+			GasPrice:      big.NewInt(80000000000),
+			Nonce:         3,
+			GasLimit:      21000,
+			SignatureType: types.Ecdsa,
+		}),
+	})
+	assert.Nil(t, err)
+	assert.Len(t, payloadsResponse.Payloads, 1)
+	assert.Equal(t, types.Ecdsa, payloadsResponse.Payloads[0].SignatureType)
+
+	// Test Combine
+	sign := func(key *ecdsa.PrivateKey) *types.Signature {
+		signature, err := crypto.Sign(payloadsResponse.Payloads[0].Bytes, key)
+		assert.NoError(t, err)
+
+		return &types.Signature{
+			SigningPayload: payloadsResponse.Payloads[0],
+			PublicKey: &types.PublicKey{
+				Bytes:     crypto.CompressPubkey(&key.PublicKey),
+				CurveType: types.Secp256k1,
+			},
+			SignatureType: types.Ecdsa,
+			Bytes:         signature[:crypto.RecoveryIDOffset],
+		}
+	}
+
+	combineResponse, err := servicer.ConstructionCombine(ctx, &types.ConstructionCombineRequest{
+		NetworkIdentifier:   networkIdentifier,
+		UnsignedTransaction: payloadsResponse.UnsignedTransaction,
+		Signatures:          []*types.Signature{sign(privateKey)},
+	})
+	assert.Nil(t, err)
+
+	signedTx, signErr := ethTypes.SignTx(
+		ethTypes.NewTransaction(3, common.HexToAddress(to), big.NewInt(1000), 21000, big.NewInt(80000000000), []byte{}),
+		ethTypes.NewLondonSigner(params.MetadiumTestnetChainConfig.ChainID),
+		privateKey,
+	)
+	assert.NoError(t, signErr)
+
+	var combinedTx ethTypes.Transaction
+	assert.NoError(t, combinedTx.UnmarshalJSON([]byte(combineResponse.SignedTransaction)))
+	assert.Equal(t, signedTx.Hash(), combinedTx.Hash())
+
+	// Test Combine (signed by another account)
+	combineResponse, err = servicer.ConstructionCombine(ctx, &types.ConstructionCombineRequest{
+		NetworkIdentifier:   networkIdentifier,
+		UnsignedTransaction: payloadsResponse.UnsignedTransaction,
+		Signatures:          []*types.Signature{sign(otherKey)},
+	})
+	assert.Nil(t, combineResponse)
+	assert.Equal(t, ErrSignatureInvalid.Code, err.Code)
+
+	// Test Combine (recoverable signature of ecdsa type)
+	signature := sign(privateKey)
+	signature.Bytes = append(signature.Bytes, 0)
+	combineResponse, err = servicer.ConstructionCombine(ctx, &types.ConstructionCombineRequest{
+		NetworkIdentifier:   networkIdentifier,
+		UnsignedTransaction: payloadsResponse.UnsignedTransaction,
+		Signatures:          []*types.Signature{signature},
+	})
+	assert.Nil(t, combineResponse)
+	assert.Equal(t, ErrSignatureInvalid.Code, err.Code)
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestConstructionSubmit_BroadcastErrors(t *testing.T) {
+	networkIdentifier = &types.NetworkIdentifier{
+		Network:    metadium.TestnetNetwork,
+		Blockchain: metadium.Blockchain,
+	}
+
+	cfg := &configuration.Configuration{
+		Mode:    configuration.Online,
+		Network: networkIdentifier,
+		Params:  params.MetadiumTestnetChainConfig,
+	}
+
+	mockClient := &mocks.Client{}
+	servicer := NewConstructionAPIService(cfg, mockClient, NewTransactionTracker(cfg, mockClient))
+	ctx := context.Background()
+
+	privateKey, keyErr := crypto.HexToECDSA(
+		"b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291",
+	)
+	assert.NoError(t, keyErr)
+
+	signedTx, signErr := ethTypes.SignTx(
+		ethTypes.NewTransaction(
+			3,
+			common.HexToAddress("0x57B414a0332B5CaB885a451c2a28a07d1e9b8a8d"),
+			big.NewInt(1000),
+			21000,
+			big.NewInt(80000000000),
+			nil,
+		),
+		ethTypes.NewLondonSigner(params.MetadiumTestnetChainConfig.ChainID),
+		privateKey,
+	)
+	assert.NoError(t, signErr)
+	signedTxJSON, marshalErr := signedTx.MarshalJSON()
+	assert.NoError(t, marshalErr)
+
+	tests := map[string]struct {
+		err       error
+		expected  *types.Error
+		retriable bool
+	}{
+		"already known": {
+			err: errors.New("already known"),
+		},
+		"nonce too low": {
+			err:      errors.New("nonce too low"),
+			expected: ErrNonceTooLow,
+		},
+		"insufficient funds": {
+			err:      errors.New("insufficient funds for gas * price + value"),
+			expected: ErrInsufficientFunds,
+		},
+		"replacement underpriced": {
+			err:      errors.New("replacement transaction underpriced"),
+			expected: ErrReplacementUnderpriced,
+		},
+		"intrinsic gas too low": {
+			err:      errors.New("intrinsic gas too low"),
+			expected: ErrIntrinsicGasTooLow,
+		},
+		"underpriced": {
+			err:      errors.New("transaction underpriced"),
+			expected: ErrUnderpriced,
+		},
+		"below base fee": {
+			err:      errors.New("max fee per gas less than block base fee: address 0x71562b71999873DB5b286dF957af199Ec94617F7"),
+			expected: ErrUnderpriced,
+		},
+		"txpool full": {
+			err:       errors.New("txpool is full"),
+			expected:  ErrTxPoolFull,
+			retriable: true,
+		},
+		"unknown": {
+			err:      errors.New("oversized data"),
+			expected: ErrBroadcastFailed,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockClient.On("SendTransaction", ctx, mock.Anything).Return(test.err).Once()
+			submitResponse, err := servicer.ConstructionSubmit(ctx, &types.ConstructionSubmitRequest{
+				NetworkIdentifier: networkIdentifier,
+				SignedTransaction: string(signedTxJSON),
+			})
+			if test.expected == nil {
+				assert.Nil(t, err)
+				assert.Equal(t, signedTx.Hash().Hex(), submitResponse.TransactionIdentifier.Hash)
+				return
+			}
+
+			assert.Nil(t, submitResponse)
+			assert.Equal(t, test.expected.Code, err.Code)
+			assert.Equal(t, test.retriable, err.Retriable)
+			assert.Equal(t, test.err.Error(), err.Details["context"])
+		})
+	}
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestConstructionPreprocess_MismatchedCurrency(t *testing.T) {
+	cfg := &configuration.Configuration{
+		Mode:   configuration.Offline,
+		Params: params.MetadiumTestnetChainConfig,
+	}
+	mockClient := &mocks.Client{}
+	servicer := NewConstructionAPIService(cfg, mockClient, NewTransactionTracker(cfg, mockClient))
+
+	token := &types.Currency{
+		Symbol:   "TKN",
+		Decimals: 18,
+		Metadata: map[string]interface{}{
+			metadium.ContractAddressKey: "0x2d74530C0C196De44d3906822053bf336F18a16e",
+		},
+	}
+	resp, err := servicer.ConstructionPreprocess(
+		context.Background(),
+		&types.ConstructionPreprocessRequest{
+			Operations: []*types.Operation{
+				{
+					OperationIdentifier: &types.OperationIdentifier{Index: 0},
+					Type:                metadium.CallOpType,
+					Account:             &types.AccountIdentifier{Address: "0xbe862AD9AbFe6f22BCb087716c7D89a26051f74C"},
+					Amount:              &types.Amount{Value: "-1000", Currency: metadium.Currency},
+				},
+				{
+					OperationIdentifier: &types.OperationIdentifier{Index: 1},
+					Type:                metadium.CallOpType,
+					Account:             &types.AccountIdentifier{Address: "0x57B414a0332B5CaB885a451c2a28a07d1e9b8a8d"},
+					Amount:              &types.Amount{Value: "1000", Currency: token},
+				},
+			},
+		},
+	)
+	assert.Nil(t, resp)
+	assert.Equal(t, ErrUnclearIntent.Code, err.Code)
+}
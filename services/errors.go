@@ -15,6 +15,8 @@
 package services
 
 import (
+	"strings"
+
 	"github.com/coinbase/rosetta-sdk-go/types"
 )
 
@@ -36,6 +38,22 @@ var (
 		ErrBlockOrphaned,
 		ErrInvalidAddress,
 		ErrGmetNotReady,
+		ErrTransactionNotFound,
+		ErrInvalidFeeTier,
+		ErrGasPriceTooHigh,
+		ErrFeeTooHigh,
+		ErrValueTooHigh,
+		ErrInvalidChainID,
+		ErrUnsupportedSignatureType,
+		ErrNonceTooLow,
+		ErrInsufficientFunds,
+		ErrReplacementUnderpriced,
+		ErrIntrinsicGasTooLow,
+		ErrUnderpriced,
+		ErrTxPoolFull,
+		ErrInvalidExtendedPublicKey,
+		ErrInvalidDerivationPath,
+		ErrUnsupportedCurrency,
 	}
 
 	// ErrUnimplemented is returned when an endpoint
@@ -144,6 +162,142 @@ var (
 		Message:   "gmet not ready",
 		Retriable: true,
 	}
+
+	// ErrTransactionNotFound is returned when a transaction
+	// requested in /block/transaction is not in the block, when
+	// a transaction requested in /mempool/transaction or with the
+	// transaction_status /call method is unknown, or when the
+	// transaction to replace in /construction/metadata is no
+	// longer pending.
+	ErrTransactionNotFound = &types.Error{
+		Code:    14, //nolint
+		Message: "Transaction not found",
+	}
+
+	// ErrInvalidFeeTier is returned when the fee tier
+	// provided in /construction/preprocess is not supported.
+	ErrInvalidFeeTier = &types.Error{
+		Code:    15, //nolint
+		Message: "Invalid fee tier",
+	}
+
+	// ErrGasPriceTooHigh is returned when the gas price of a
+	// transaction exceeds the configured maximum.
+	ErrGasPriceTooHigh = &types.Error{
+		Code:    16, //nolint
+		Message: "Gas price exceeds maximum",
+	}
+
+	// ErrFeeTooHigh is returned when the fee a transaction
+	// can be charged exceeds the configured maximum.
+	ErrFeeTooHigh = &types.Error{
+		Code:    17, //nolint
+		Message: "Fee exceeds maximum",
+	}
+
+	// ErrValueTooHigh is returned when the value of a
+	// transaction exceeds the configured maximum.
+	ErrValueTooHigh = &types.Error{
+		Code:    18, //nolint
+		Message: "Value exceeds maximum",
+	}
+
+	// ErrInvalidChainID is returned when the chain ID of
+	// a transaction is not the chain ID of the network.
+	ErrInvalidChainID = &types.Error{
+		Code:    19, //nolint
+		Message: "Invalid chain ID",
+	}
+
+	// ErrUnsupportedSignatureType is returned when the signature
+	// type requested in /construction/preprocess is not supported.
+	ErrUnsupportedSignatureType = &types.Error{
+		Code:    20, //nolint
+		Message: "Unsupported signature type",
+	}
+
+	// ErrNonceTooLow is returned when the nonce of a broadcast
+	// transaction was already used by the sender.
+	ErrNonceTooLow = &types.Error{
+		Code:    21, //nolint
+		Message: "Nonce too low",
+	}
+
+	// ErrInsufficientFunds is returned when the sender of a broadcast
+	// transaction cannot afford its value and maximum fee.
+	ErrInsufficientFunds = &types.Error{
+		Code:    22, //nolint
+		Message: "Insufficient funds",
+	}
+
+	// ErrReplacementUnderpriced is returned when a broadcast transaction
+	// replaces a pending transaction without raising its fees enough.
+	ErrReplacementUnderpriced = &types.Error{
+		Code:    23, //nolint
+		Message: "Replacement transaction underpriced",
+	}
+
+	// ErrIntrinsicGasTooLow is returned when the gas limit of a
+	// broadcast transaction is below its intrinsic gas.
+	ErrIntrinsicGasTooLow = &types.Error{
+		Code:    24, //nolint
+		Message: "Intrinsic gas too low",
+	}
+
+	// ErrUnderpriced is returned when the fees of a broadcast
+	// transaction are below the minimum accepted by gmet.
+	ErrUnderpriced = &types.Error{
+		Code:    25, //nolint
+		Message: "Transaction underpriced",
+	}
+
+	// ErrTxPoolFull is returned when gmet has no room for
+	// a broadcast transaction in its txpool.
+	ErrTxPoolFull = &types.Error{
+		Code:      26, //nolint
+		Message:   "Transaction pool full",
+		Retriable: true,
+	}
+
+	// ErrInvalidExtendedPublicKey is returned when the xpub
+	// provided in /construction/derive cannot be decoded or
+	// does not match the provided public key.
+	ErrInvalidExtendedPublicKey = &types.Error{
+		Code:    27, //nolint
+		Message: "Invalid extended public key",
+	}
+
+	// ErrInvalidDerivationPath is returned when the derivation
+	// path provided in /construction/derive cannot be derived
+	// from the provided xpub.
+	ErrInvalidDerivationPath = &types.Error{
+		Code:    28, //nolint
+		Message: "Invalid derivation path",
+	}
+
+	// ErrUnsupportedCurrency is returned when /account/balance
+	// is called for a currency that is neither META nor an
+	// ERC-20 token.
+	ErrUnsupportedCurrency = &types.Error{
+		Code:    29, //nolint
+		Message: "Unsupported currency",
+	}
+
+	// broadcastErrors maps the errors returned by the txpool of gmet
+	// to the errors returned by /construction/submit. Errors are
+	// matched in order, as some messages contain others.
+	broadcastErrors = []struct {
+		message string
+		rErr    *types.Error
+	}{
+		{"nonce too low", ErrNonceTooLow},
+		{"insufficient funds", ErrInsufficientFunds},
+		{"replacement transaction underpriced", ErrReplacementUnderpriced},
+		{"intrinsic gas too low", ErrIntrinsicGasTooLow},
+		{"transaction underpriced", ErrUnderpriced},
+		{"max fee per gas less than block base fee", ErrUnderpriced},
+		{"txpool is full", ErrTxPoolFull},
+	}
 )
 
 // wrapErr adds details to the types.Error provided. We use a function
@@ -163,3 +317,22 @@ func wrapErr(rErr *types.Error, err error) *types.Error {
 
 	return newErr
 }
+
+// broadcastErr returns the error of /construction/submit for err,
+// an error returned by gmet when broadcasting a transaction.
+func broadcastErr(err error) *types.Error {
+	for _, broadcastError := range broadcastErrors {
+		if strings.Contains(err.Error(), broadcastError.message) {
+			return wrapErr(broadcastError.rErr, err)
+		}
+	}
+
+	return wrapErr(ErrBroadcastFailed, err)
+}
+
+// isAlreadyKnown returns true if err, an error returned by
+// gmet when broadcasting a transaction, is returned because
+// the transaction is already in its txpool.
+func isAlreadyKnown(err error) bool {
+	return strings.Contains(err.Error(), "already known")
+}
@@ -0,0 +1,202 @@
+// Copyright 2020 Coinbase, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// extendedKeyLength is the length of a serialized
+	// BIP-32 extended key, without its checksum.
+	extendedKeyLength = 78
+
+	// checksumLength is the length of the
+	// base58check checksum of an extended key.
+	checksumLength = 4
+
+	// hardenedKeyStart is the index of
+	// the first hardened child key.
+	hardenedKeyStart = 0x80000000
+)
+
+var (
+	// extendedPublicKeyVersions are the version bytes
+	// of mainnet (xpub) and testnet (tpub) extended
+	// public keys.
+	extendedPublicKeyVersions = [][]byte{
+		{0x04, 0x88, 0xb2, 0x1e},
+		{0x04, 0x35, 0x87, 0xcf},
+	}
+
+	base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+)
+
+// extendedPublicKey is a BIP-32 extended public key.
+type extendedPublicKey struct {
+	depth       uint8
+	childNumber uint32
+	chainCode   []byte
+	key         *ecdsa.PublicKey
+}
+
+// parseExtendedPublicKey decodes a base58check
+// encoded BIP-32 extended public key.
+func parseExtendedPublicKey(s string) (*extendedPublicKey, error) {
+	decoded, err := base58Decode(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(decoded) != extendedKeyLength+checksumLength {
+		return nil, fmt.Errorf("invalid extended key length %d", len(decoded))
+	}
+
+	payload := decoded[:extendedKeyLength]
+	if !bytes.Equal(checksum(payload), decoded[extendedKeyLength:]) {
+		return nil, errors.New("invalid extended key checksum")
+	}
+
+	validVersion := false
+	for _, version := range extendedPublicKeyVersions {
+		if bytes.Equal(payload[:4], version) {
+			validVersion = true
+		}
+	}
+	if !validVersion {
+		return nil, errors.New("not an extended public key")
+	}
+
+	key, err := crypto.DecompressPubkey(payload[45:])
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid extended key public key", err)
+	}
+
+	return &extendedPublicKey{
+		depth:       payload[4],
+		childNumber: binary.BigEndian.Uint32(payload[9:13]),
+		chainCode:   payload[13:45],
+		key:         key,
+	}, nil
+}
+
+// derive returns the non-hardened child key of k with index i.
+func (k *extendedPublicKey) derive(i uint32) (*extendedPublicKey, error) {
+	if i >= hardenedKeyStart {
+		return nil, errors.New("cannot derive a hardened key from a public key")
+	}
+
+	index := make([]byte, 4) // nolint:gomnd
+	binary.BigEndian.PutUint32(index, i)
+	data := append(crypto.CompressPubkey(k.key), index...)
+
+	mac := hmac.New(sha512.New, k.chainCode)
+	mac.Write(data) // nolint:errcheck
+	sum := mac.Sum(nil)
+
+	curve := crypto.S256()
+	il := new(big.Int).SetBytes(sum[:32])
+	if il.Cmp(curve.Params().N) >= 0 {
+		return nil, fmt.Errorf("invalid child key %d", i)
+	}
+
+	x, y := curve.ScalarBaseMult(sum[:32])
+	x, y = curve.Add(x, y, k.key.X, k.key.Y)
+	if x.Sign() == 0 && y.Sign() == 0 {
+		return nil, fmt.Errorf("invalid child key %d", i)
+	}
+
+	return &extendedPublicKey{
+		depth:       k.depth + 1,
+		childNumber: i,
+		chainCode:   sum[32:],
+		key:         &ecdsa.PublicKey{Curve: curve, X: x, Y: y},
+	}, nil
+}
+
+// derivePath derives the key at the absolute path from k. The
+// components of path up to the depth of k must lead to k, and
+// the following components must not be hardened.
+func (k *extendedPublicKey) derivePath(path string) (*extendedPublicKey, error) {
+	if !strings.HasPrefix(strings.TrimSpace(path), "m/") {
+		return nil, errors.New("derivation path must start with m/")
+	}
+
+	components, err := accounts.ParseDerivationPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	depth := int(k.depth)
+	if len(components) < depth {
+		return nil, fmt.Errorf(
+			"derivation path is shallower than the extended key depth %d",
+			depth,
+		)
+	}
+	if depth > 0 && components[depth-1] != k.childNumber {
+		return nil, errors.New("derivation path does not lead to the extended key")
+	}
+
+	child := k
+	for _, i := range components[depth:] {
+		if child, err = child.derive(i); err != nil {
+			return nil, err
+		}
+	}
+
+	return child, nil
+}
+
+// base58Decode decodes s with the bitcoin base58 alphabet.
+func base58Decode(s string) ([]byte, error) {
+	n := new(big.Int)
+	radix := big.NewInt(int64(len(base58Alphabet)))
+	for _, c := range s {
+		digit := strings.IndexRune(base58Alphabet, c)
+		if digit < 0 {
+			return nil, fmt.Errorf("invalid base58 character %q", c)
+		}
+		n.Mul(n, radix)
+		n.Add(n, big.NewInt(int64(digit)))
+	}
+
+	// Leading zero bytes are encoded as leading ones.
+	zeros := 0
+	for zeros < len(s) && s[zeros] == base58Alphabet[0] {
+		zeros++
+	}
+
+	return append(make([]byte, zeros), n.Bytes()...), nil
+}
+
+// checksum returns the base58check checksum of b.
+func checksum(b []byte) []byte {
+	first := sha256.Sum256(b)
+	second := sha256.Sum256(first[:])
+	return second[:checksumLength]
+}
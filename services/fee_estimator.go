@@ -0,0 +1,93 @@
+// Copyright 2020 Coinbase, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"context"
+	"math/big"
+	"sort"
+)
+
+// feeTier is how quickly a transaction should be included. It is
+// selected with the fee_tier metadata of /construction/preprocess.
+type feeTier string
+
+const (
+	slowFeeTier   feeTier = "slow"
+	normalFeeTier feeTier = "normal"
+	fastFeeTier   feeTier = "fast"
+
+	// feeHistoryBlocks is the number of recent
+	// blocks sampled to suggest fees.
+	feeHistoryBlocks = 20
+)
+
+// feeTierPercentiles are the percentiles of the priority
+// fees paid in recent blocks suggested for each tier.
+var feeTierPercentiles = map[feeTier]float64{
+	slowFeeTier:   10,
+	normalFeeTier: 50,
+	fastFeeTier:   90,
+}
+
+// isValid returns true if t is a supported fee tier.
+func (t feeTier) isValid() bool {
+	_, ok := feeTierPercentiles[t]
+	return ok
+}
+
+// feeEstimator suggests fees from the fees paid in recent blocks.
+type feeEstimator struct {
+	client Client
+}
+
+// newFeeEstimator returns a feeEstimator sampling blocks from client.
+func newFeeEstimator(client Client) *feeEstimator {
+	return &feeEstimator{
+		client: client,
+	}
+}
+
+// suggest returns the priority fee per gas suggested for tier: the
+// median over recent blocks with transactions of the percentile of
+// tier of the priority fees paid in each block. Before London, the
+// priority fee is the gas price. It returns nil if none of the
+// recent blocks have transactions.
+func (e *feeEstimator) suggest(ctx context.Context, tier feeTier) (*big.Int, error) {
+	history, err := e.client.FeeHistory(ctx, feeHistoryBlocks, []float64{feeTierPercentiles[tier]})
+	if err != nil {
+		return nil, err
+	}
+
+	var fees []*big.Int
+	for i, rewards := range history.Reward {
+		// Empty blocks report a priority fee of zero.
+		if i >= len(history.GasUsedRatio) || history.GasUsedRatio[i] == 0 || len(rewards) == 0 {
+			continue
+		}
+
+		fees = append(fees, rewards[0])
+	}
+
+	if len(fees) == 0 {
+		return nil, nil
+	}
+
+	sort.Slice(fees, func(i, j int) bool {
+		return fees[i].Cmp(fees[j]) < 0
+	})
+
+	return new(big.Int).Set(fees[len(fees)/2]), nil // nolint:gomnd
+}
@@ -0,0 +1,88 @@
+// Copyright 2020 Coinbase, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/metadium/rosetta-metadium/metadium"
+	mocks "github.com/metadium/rosetta-metadium/mocks/services"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeeEstimator(t *testing.T) {
+	gwei := func(n int64) *big.Int {
+		return new(big.Int).Mul(big.NewInt(n), big.NewInt(1000000000))
+	}
+
+	tests := map[string]struct {
+		tier    feeTier
+		history *metadium.FeeHistory
+		err     error
+
+		expected    *big.Int
+		expectedErr bool
+	}{
+		"median of blocks with transactions": {
+			tier: fastFeeTier,
+			history: &metadium.FeeHistory{
+				OldestBlock:  big.NewInt(100),
+				Reward:       [][]*big.Int{{gwei(100)}, {gwei(0)}, {gwei(80)}, {gwei(120)}},
+				GasUsedRatio: []float64{0.5, 0, 0.1, 0.9},
+			},
+			expected: gwei(100),
+		},
+		"no transactions": {
+			tier: slowFeeTier,
+			history: &metadium.FeeHistory{
+				OldestBlock:  big.NewInt(100),
+				Reward:       [][]*big.Int{{gwei(0)}, {gwei(0)}},
+				GasUsedRatio: []float64{0, 0},
+			},
+		},
+		"gmet error": {
+			tier:        normalFeeTier,
+			err:         errors.New("method not found"),
+			expectedErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			mockClient := &mocks.Client{}
+			mockClient.On(
+				"FeeHistory",
+				ctx,
+				uint64(feeHistoryBlocks),
+				[]float64{feeTierPercentiles[test.tier]},
+			).Return(test.history, test.err).Once()
+
+			fee, err := newFeeEstimator(mockClient).suggest(ctx, test.tier)
+			if test.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.expected, fee)
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
@@ -16,6 +16,10 @@ package services
 
 import (
 	"context"
+	"errors"
+
+	"github.com/metadium/rosetta-metadium/configuration"
+	"github.com/metadium/rosetta-metadium/metadium"
 
 	"github.com/coinbase/rosetta-sdk-go/server"
 	"github.com/coinbase/rosetta-sdk-go/types"
@@ -23,11 +27,19 @@ import (
 
 // MempoolAPIService implements the server.MempoolAPIServicer interface.
 type MempoolAPIService struct {
+	config *configuration.Configuration
+	client Client
 }
 
 // NewMempoolAPIService creates a new instance of a MempoolAPIService.
-func NewMempoolAPIService() server.MempoolAPIServicer {
-	return &MempoolAPIService{}
+func NewMempoolAPIService(
+	cfg *configuration.Configuration,
+	client Client,
+) server.MempoolAPIServicer {
+	return &MempoolAPIService{
+		config: cfg,
+		client: client,
+	}
 }
 
 // Mempool implements the /mempool endpoint.
@@ -35,7 +47,18 @@ func (s *MempoolAPIService) Mempool(
 	ctx context.Context,
 	request *types.NetworkRequest,
 ) (*types.MempoolResponse, *types.Error) {
-	return nil, wrapErr(ErrUnimplemented, nil)
+	if s.config.Mode != configuration.Online {
+		return nil, ErrUnavailableOffline
+	}
+
+	identifiers, err := s.client.Mempool(ctx)
+	if err != nil {
+		return nil, wrapErr(ErrGmet, err)
+	}
+
+	return &types.MempoolResponse{
+		TransactionIdentifiers: identifiers,
+	}, nil
 }
 
 // MempoolTransaction implements the /mempool/transaction endpoint.
@@ -43,5 +66,19 @@ func (s *MempoolAPIService) MempoolTransaction(
 	ctx context.Context,
 	request *types.MempoolTransactionRequest,
 ) (*types.MempoolTransactionResponse, *types.Error) {
-	return nil, wrapErr(ErrUnimplemented, nil)
+	if s.config.Mode != configuration.Online {
+		return nil, ErrUnavailableOffline
+	}
+
+	transaction, err := s.client.MempoolTransaction(ctx, request.TransactionIdentifier)
+	if errors.Is(err, metadium.ErrTransactionNotFound) {
+		return nil, wrapErr(ErrTransactionNotFound, err)
+	}
+	if err != nil {
+		return nil, wrapErr(ErrGmet, err)
+	}
+
+	return &types.MempoolTransactionResponse{
+		Transaction: transaction,
+	}, nil
 }
@@ -18,20 +18,99 @@ import (
 	"context"
 	"testing"
 
+	"github.com/metadium/rosetta-metadium/configuration"
+	"github.com/metadium/rosetta-metadium/metadium"
+	mocks "github.com/metadium/rosetta-metadium/mocks/services"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
 	"github.com/stretchr/testify/assert"
 )
 
-func TestMempoolEndpoints(t *testing.T) {
-	servicer := NewMempoolAPIService()
+func TestMempoolService_Offline(t *testing.T) {
+	cfg := &configuration.Configuration{
+		Mode: configuration.Offline,
+	}
+	mockClient := &mocks.Client{}
+	servicer := NewMempoolAPIService(cfg, mockClient)
 	ctx := context.Background()
 
 	mem, err := servicer.Mempool(ctx, nil)
 	assert.Nil(t, mem)
-	assert.Equal(t, ErrUnimplemented.Code, err.Code)
-	assert.Equal(t, ErrUnimplemented.Message, err.Message)
+	assert.Equal(t, ErrUnavailableOffline.Code, err.Code)
+	assert.Equal(t, ErrUnavailableOffline.Message, err.Message)
 
 	memTransaction, err := servicer.MempoolTransaction(ctx, nil)
 	assert.Nil(t, memTransaction)
-	assert.Equal(t, ErrUnimplemented.Code, err.Code)
-	assert.Equal(t, ErrUnimplemented.Message, err.Message)
+	assert.Equal(t, ErrUnavailableOffline.Code, err.Code)
+	assert.Equal(t, ErrUnavailableOffline.Message, err.Message)
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestMempoolService_Online(t *testing.T) {
+	cfg := &configuration.Configuration{
+		Mode: configuration.Online,
+	}
+	mockClient := &mocks.Client{}
+	servicer := NewMempoolAPIService(cfg, mockClient)
+	ctx := context.Background()
+
+	transactionIdentifier := &types.TransactionIdentifier{
+		Hash: "tx 1",
+	}
+
+	t.Run("mempool", func(t *testing.T) {
+		mockClient.On(
+			"Mempool",
+			ctx,
+		).Return(
+			[]*types.TransactionIdentifier{transactionIdentifier},
+			nil,
+		).Once()
+		mem, err := servicer.Mempool(ctx, &types.NetworkRequest{})
+		assert.Nil(t, err)
+		assert.Equal(t, &types.MempoolResponse{
+			TransactionIdentifiers: []*types.TransactionIdentifier{transactionIdentifier},
+		}, mem)
+	})
+
+	t.Run("mempool transaction", func(t *testing.T) {
+		transaction := &types.Transaction{
+			TransactionIdentifier: transactionIdentifier,
+		}
+		mockClient.On(
+			"MempoolTransaction",
+			ctx,
+			transactionIdentifier,
+		).Return(
+			transaction,
+			nil,
+		).Once()
+		memTransaction, err := servicer.MempoolTransaction(ctx, &types.MempoolTransactionRequest{
+			TransactionIdentifier: transactionIdentifier,
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, &types.MempoolTransactionResponse{
+			Transaction: transaction,
+		}, memTransaction)
+	})
+
+	t.Run("transaction not found", func(t *testing.T) {
+		mockClient.On(
+			"MempoolTransaction",
+			ctx,
+			transactionIdentifier,
+		).Return(
+			nil,
+			metadium.ErrTransactionNotFound,
+		).Once()
+		memTransaction, err := servicer.MempoolTransaction(ctx, &types.MempoolTransactionRequest{
+			TransactionIdentifier: transactionIdentifier,
+		})
+		assert.Nil(t, memTransaction)
+		assert.Equal(t, ErrTransactionNotFound.Code, err.Code)
+		assert.Equal(t, ErrTransactionNotFound.Message, err.Message)
+	})
+
+	mockClient.AssertExpectations(t)
 }
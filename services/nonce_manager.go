@@ -0,0 +1,117 @@
+// Copyright 2020 Coinbase, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// nonceManager tracks the nonces handed out by /construction/metadata,
+// so that constructions for the same sender started before any of them
+// is broadcast get distinct nonces. A reservation is dropped once the
+// pending nonce of its sender passes it, once it expires or once the
+// broadcast of its transaction fails.
+type nonceManager struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu           sync.Mutex
+	reservations map[common.Address]map[uint64]time.Time
+}
+
+// newNonceManager returns a nonceManager whose reservations
+// expire after ttl. Reservations are disabled if ttl is zero.
+func newNonceManager(ttl time.Duration) *nonceManager {
+	return &nonceManager{
+		ttl:          ttl,
+		now:          time.Now,
+		reservations: map[common.Address]map[uint64]time.Time{},
+	}
+}
+
+// reserve reserves count consecutive nonces for address and returns
+// the first of them. pendingNonce is the nonce of address once all
+// of its transactions in the txpool are included, so the lowest
+// available nonce not below it is returned.
+func (m *nonceManager) reserve(address common.Address, pendingNonce uint64, count uint64) uint64 {
+	if m.ttl <= 0 {
+		return pendingNonce
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	m.reconcile(address, pendingNonce, now)
+
+	reserved, ok := m.reservations[address]
+	if !ok {
+		reserved = map[uint64]time.Time{}
+		m.reservations[address] = reserved
+	}
+
+	nonce := pendingNonce
+	for i := uint64(0); i < count; {
+		if _, ok := reserved[nonce+i]; ok {
+			nonce += i + 1
+			i = 0
+			continue
+		}
+		i++
+	}
+
+	expiry := now.Add(m.ttl)
+	for i := uint64(0); i < count; i++ {
+		reserved[nonce+i] = expiry
+	}
+
+	return nonce
+}
+
+// release drops the reservation of nonce for address.
+func (m *nonceManager) release(address common.Address, nonce uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	reserved, ok := m.reservations[address]
+	if !ok {
+		return
+	}
+
+	delete(reserved, nonce)
+	if len(reserved) == 0 {
+		delete(m.reservations, address)
+	}
+}
+
+// reconcile drops the reservations of address below pendingNonce,
+// which the txpool or the chain already account for, and the
+// expired reservations of all addresses.
+func (m *nonceManager) reconcile(address common.Address, pendingNonce uint64, now time.Time) {
+	for reservedAddress, reserved := range m.reservations {
+		for nonce, expiry := range reserved {
+			if !now.Before(expiry) || (reservedAddress == address && nonce < pendingNonce) {
+				delete(reserved, nonce)
+			}
+		}
+
+		if len(reserved) == 0 {
+			delete(m.reservations, reservedAddress)
+		}
+	}
+}
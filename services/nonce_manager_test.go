@@ -0,0 +1,66 @@
+// Copyright 2020 Coinbase, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNonceManager(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	m := newNonceManager(time.Minute)
+	m.now = func() time.Time { return now }
+
+	from := common.HexToAddress("0x71562b71999873DB5b286dF957af199Ec94617F7")
+	other := common.HexToAddress("0x57B414a0332B5CaB885a451c2a28a07d1e9b8a8d")
+
+	// Concurrent constructions get consecutive nonces
+	assert.Equal(t, uint64(3), m.reserve(from, 3, 1))
+	assert.Equal(t, uint64(4), m.reserve(from, 3, 1))
+	assert.Equal(t, uint64(5), m.reserve(from, 3, 2))
+	assert.Equal(t, uint64(0), m.reserve(other, 0, 1))
+
+	// Released nonces are handed out again
+	m.release(from, 4)
+	assert.Equal(t, uint64(4), m.reserve(from, 3, 1))
+
+	// A batch skips gaps too small for it
+	m.release(from, 3)
+	assert.Equal(t, uint64(7), m.reserve(from, 3, 2))
+	assert.Equal(t, uint64(3), m.reserve(from, 3, 1))
+
+	// Nonces below the pending nonce are already in the txpool
+	assert.Equal(t, uint64(9), m.reserve(from, 8, 1))
+	assert.Equal(t, uint64(12), m.reserve(from, 12, 1))
+
+	// Expired reservations are dropped
+	now = now.Add(time.Minute)
+	assert.Equal(t, uint64(12), m.reserve(from, 12, 1))
+	assert.Equal(t, uint64(0), m.reserve(other, 0, 1))
+	assert.Len(t, m.reservations, 2)
+}
+
+func TestNonceManager_Disabled(t *testing.T) {
+	m := newNonceManager(0)
+	from := common.HexToAddress("0x71562b71999873DB5b286dF957af199Ec94617F7")
+
+	assert.Equal(t, uint64(3), m.reserve(from, 3, 1))
+	assert.Equal(t, uint64(3), m.reserve(from, 3, 1))
+	assert.Empty(t, m.reservations)
+}
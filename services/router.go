@@ -28,6 +28,7 @@ import (
 func NewBlockchainRouter(
 	config *configuration.Configuration,
 	client Client,
+	tracker *TransactionTracker,
 	asserter *asserter.Asserter,
 ) http.Handler {
 	networkAPIService := NewNetworkAPIService(config, client)
@@ -48,19 +49,19 @@ func NewBlockchainRouter(
 		asserter,
 	)
 
-	constructionAPIService := NewConstructionAPIService(config, client)
+	constructionAPIService := NewConstructionAPIService(config, client, tracker)
 	constructionAPIController := server.NewConstructionAPIController(
 		constructionAPIService,
 		asserter,
 	)
 
-	mempoolAPIService := NewMempoolAPIService()
+	mempoolAPIService := NewMempoolAPIService(config, client)
 	mempoolAPIController := server.NewMempoolAPIController(
 		mempoolAPIService,
 		asserter,
 	)
 
-	callAPIService := NewCallAPIService(config, client)
+	callAPIService := NewCallAPIService(config, client, tracker)
 	callAPIController := server.NewCallAPIController(
 		callAPIService,
 		asserter,
@@ -0,0 +1,254 @@
+// Copyright 2020 Coinbase, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/metadium/rosetta-metadium/configuration"
+	"github.com/metadium/rosetta-metadium/metadium"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// transactionStatus is the status of a submitted transaction.
+type transactionStatus string
+
+const (
+	// pendingStatus is the status of a transaction in the txpool.
+	pendingStatus transactionStatus = "pending"
+
+	// minedStatus is the status of a transaction in a block.
+	minedStatus transactionStatus = "mined"
+
+	// droppedStatus is the status of a transaction that left the
+	// txpool without being mined. It can be broadcast again.
+	droppedStatus transactionStatus = "dropped"
+
+	// replacedStatus is the status of a transaction whose nonce
+	// was used by another transaction of its sender.
+	replacedStatus transactionStatus = "replaced"
+
+	// trackedTransactionTTL is how long a submitted
+	// transaction is tracked after its submission.
+	trackedTransactionTTL = 24 * time.Hour
+)
+
+// trackedTransaction is a transaction submitted
+// with /construction/submit.
+type trackedTransaction struct {
+	hash        common.Hash
+	from        common.Address
+	nonce       uint64
+	submittedAt time.Time
+
+	// signedTx is the submitted transaction. For fee-delegated
+	// transactions, this is the sender transaction and rawTx is
+	// the fee-delegated transaction, with hash, to rebroadcast.
+	signedTx *ethTypes.Transaction
+	rawTx    []byte
+}
+
+// trackedTransactionStatus is the result of the
+// transaction_status /call method.
+type trackedTransactionStatus struct {
+	Hash        string            `json:"hash"`
+	From        string            `json:"from"`
+	Nonce       uint64            `json:"nonce"`
+	SubmittedAt int64             `json:"submitted_at"`
+	Status      transactionStatus `json:"status"`
+
+	// BlockIndex, BlockHash and Reverted are
+	// only populated for mined transactions.
+	BlockIndex int64  `json:"block_index,omitempty"`
+	BlockHash  string `json:"block_hash,omitempty"`
+	Reverted   bool   `json:"reverted,omitempty"`
+
+	// ReplacedBy is only populated for transactions replaced
+	// by a transaction that is still in the txpool.
+	ReplacedBy string `json:"replaced_by,omitempty"`
+}
+
+// TransactionTracker records the transactions submitted with
+// /construction/submit, to follow them through the txpool until
+// they are mined, and optionally rebroadcasts those dropped from
+// the txpool.
+type TransactionTracker struct {
+	client   Client
+	interval time.Duration
+	now      func() time.Time
+
+	mu  sync.Mutex
+	txs map[common.Hash]*trackedTransaction
+}
+
+// NewTransactionTracker returns a TransactionTracker following
+// transactions with client. Dropped transactions are rebroadcast
+// every RebroadcastInterval of cfg by Rebroadcast.
+func NewTransactionTracker(cfg *configuration.Configuration, client Client) *TransactionTracker {
+	return &TransactionTracker{
+		client:   client,
+		interval: cfg.RebroadcastInterval,
+		now:      time.Now,
+		txs:      map[common.Hash]*trackedTransaction{},
+	}
+}
+
+// record starts tracking a submitted transaction and stops
+// tracking the transactions submitted before the TTL.
+func (t *TransactionTracker) record(tx *trackedTransaction) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	for hash, tracked := range t.txs {
+		if now.Sub(tracked.submittedAt) >= trackedTransactionTTL {
+			delete(t.txs, hash)
+		}
+	}
+
+	tx.submittedAt = now
+	t.txs[tx.hash] = tx
+}
+
+// get returns the tracked transaction with hash,
+// or nil if the transaction is not tracked.
+func (t *TransactionTracker) get(hash common.Hash) *trackedTransaction {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.txs[hash]
+}
+
+// tracked returns all tracked transactions.
+func (t *TransactionTracker) tracked() []*trackedTransaction {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	txs := make([]*trackedTransaction, 0, len(t.txs))
+	for _, tx := range t.txs {
+		txs = append(txs, tx)
+	}
+
+	return txs
+}
+
+// status returns the status of tx from its receipt, the txpool
+// and the pending nonce of its sender. A transaction that is
+// neither mined nor in the txpool was replaced if its nonce
+// was used, and dropped otherwise.
+func (t *TransactionTracker) status(
+	ctx context.Context,
+	tx *trackedTransaction,
+) (*trackedTransactionStatus, error) {
+	status := &trackedTransactionStatus{
+		Hash:        tx.hash.Hex(),
+		From:        tx.from.Hex(),
+		Nonce:       tx.nonce,
+		SubmittedAt: tx.submittedAt.UnixNano() / int64(time.Millisecond),
+	}
+
+	receipt, err := t.client.TransactionReceipt(ctx, tx.hash)
+	if err == nil && receipt.BlockNumber == nil {
+		// A receipt without a block number is not final, so
+		// the transaction is reported as still pending.
+		status.Status = pendingStatus
+		return status, nil
+	}
+	if err == nil {
+		status.Status = minedStatus
+		status.BlockIndex = receipt.BlockNumber.Int64()
+		status.BlockHash = receipt.BlockHash.Hex()
+		status.Reverted = receipt.Status == ethTypes.ReceiptStatusFailed
+		return status, nil
+	}
+	if !errors.Is(err, ethereum.NotFound) {
+		return nil, err
+	}
+
+	// The hash of a fee-delegated transaction is not the hash
+	// of its sender transaction, so the submitted hash is
+	// compared with the hash of the transaction in the txpool.
+	_, pendingHash, err := t.client.PendingTransaction(ctx, tx.from, tx.nonce)
+	if err == nil {
+		if pendingHash == tx.hash {
+			status.Status = pendingStatus
+		} else {
+			status.Status = replacedStatus
+			status.ReplacedBy = pendingHash.Hex()
+		}
+		return status, nil
+	}
+	if !errors.Is(err, metadium.ErrTransactionNotFound) {
+		return nil, err
+	}
+
+	pendingNonce, err := t.client.PendingNonceAt(ctx, tx.from)
+	if err != nil {
+		return nil, err
+	}
+
+	if pendingNonce > tx.nonce {
+		status.Status = replacedStatus
+	} else {
+		status.Status = droppedStatus
+	}
+
+	return status, nil
+}
+
+// Rebroadcast broadcasts the tracked transactions dropped from
+// the txpool again, every rebroadcast interval, until ctx is
+// done. It returns immediately if the interval is zero.
+func (t *TransactionTracker) Rebroadcast(ctx context.Context) error {
+	if t.interval <= 0 {
+		return nil
+	}
+
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			t.rebroadcastDropped(ctx)
+		}
+	}
+}
+
+// rebroadcastDropped broadcasts the tracked transactions
+// dropped from the txpool again. Transactions whose status
+// or broadcast fails are retried on the next interval.
+func (t *TransactionTracker) rebroadcastDropped(ctx context.Context) {
+	for _, tx := range t.tracked() {
+		status, err := t.status(ctx, tx)
+		if err != nil || status.Status != droppedStatus {
+			continue
+		}
+
+		if len(tx.rawTx) > 0 {
+			_ = t.client.SendRawTransaction(ctx, tx.rawTx)
+		} else {
+			_ = t.client.SendTransaction(ctx, tx.signedTx)
+		}
+	}
+}
@@ -0,0 +1,266 @@
+// Copyright 2020 Coinbase, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/metadium/rosetta-metadium/configuration"
+	"github.com/metadium/rosetta-metadium/metadium"
+	mocks "github.com/metadium/rosetta-metadium/mocks/services"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTransactionTracker(t *testing.T) {
+	networkIdentifier = &types.NetworkIdentifier{
+		Network:    metadium.TestnetNetwork,
+		Blockchain: metadium.Blockchain,
+	}
+
+	cfg := &configuration.Configuration{
+		Mode:    configuration.Online,
+		Network: networkIdentifier,
+		Params:  params.MetadiumTestnetChainConfig,
+	}
+
+	mockClient := &mocks.Client{}
+	tracker := NewTransactionTracker(cfg, mockClient)
+	now := time.Unix(1600000000, 0)
+	tracker.now = func() time.Time { return now }
+	constructionServicer := NewConstructionAPIService(cfg, mockClient, tracker)
+	callServicer := NewCallAPIService(cfg, mockClient, tracker)
+	ctx := context.Background()
+
+	privateKey, keyErr := crypto.HexToECDSA(
+		"b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291",
+	)
+	assert.NoError(t, keyErr)
+
+	from := common.HexToAddress("0x71562b71999873DB5b286dF957af199Ec94617F7")
+	to := common.HexToAddress("0x57B414a0332B5CaB885a451c2a28a07d1e9b8a8d")
+	signer := ethTypes.NewLondonSigner(params.MetadiumTestnetChainConfig.ChainID)
+	signTx := func(gasPrice int64) *ethTypes.Transaction {
+		signedTx, err := ethTypes.SignTx(
+			ethTypes.NewTransaction(3, to, big.NewInt(1000), 21000, big.NewInt(gasPrice), nil),
+			signer,
+			privateKey,
+		)
+		assert.NoError(t, err)
+
+		return signedTx
+	}
+	signedTx := signTx(80000000000)
+	replacementTx := signTx(90000000000)
+
+	// Test Submit
+	signedTxJSON, marshalErr := signedTx.MarshalJSON()
+	assert.NoError(t, marshalErr)
+	mockClient.On("SendTransaction", ctx, mock.Anything).Return(nil).Once()
+	_, err := constructionServicer.ConstructionSubmit(ctx, &types.ConstructionSubmitRequest{
+		NetworkIdentifier: networkIdentifier,
+		SignedTransaction: string(signedTxJSON),
+	})
+	assert.Nil(t, err)
+
+	notFound := fmt.Errorf("%w: no pending transaction", metadium.ErrTransactionNotFound)
+	tests := map[string]struct {
+		receipt      *metadium.Receipt
+		pendingTx    *ethTypes.Transaction
+		pendingNonce uint64
+
+		expected *trackedTransactionStatus
+	}{
+		"pending": {
+			pendingTx: signedTx,
+			expected: &trackedTransactionStatus{
+				Status: pendingStatus,
+			},
+		},
+		"mined": {
+			receipt: &metadium.Receipt{
+				Status:      ethTypes.ReceiptStatusSuccessful,
+				BlockNumber: big.NewInt(14497230),
+				BlockHash:   common.HexToHash("0x54849b67df3390cec858b4a77b1d4dc818ac6854a76950854cce8b871a1f117a"),
+			},
+			expected: &trackedTransactionStatus{
+				Status:     minedStatus,
+				BlockIndex: 14497230,
+				BlockHash:  "0x54849b67df3390cec858b4a77b1d4dc818ac6854a76950854cce8b871a1f117a",
+			},
+		},
+		"pending (receipt without block)": {
+			receipt: &metadium.Receipt{
+				Status: ethTypes.ReceiptStatusSuccessful,
+			},
+			expected: &trackedTransactionStatus{
+				Status: pendingStatus,
+			},
+		},
+		"mined (reverted)": {
+			receipt: &metadium.Receipt{
+				Status:      ethTypes.ReceiptStatusFailed,
+				BlockNumber: big.NewInt(14497230),
+				BlockHash:   common.HexToHash("0x54849b67df3390cec858b4a77b1d4dc818ac6854a76950854cce8b871a1f117a"),
+			},
+			expected: &trackedTransactionStatus{
+				Status:     minedStatus,
+				BlockIndex: 14497230,
+				BlockHash:  "0x54849b67df3390cec858b4a77b1d4dc818ac6854a76950854cce8b871a1f117a",
+				Reverted:   true,
+			},
+		},
+		"replaced (pending)": {
+			pendingTx: replacementTx,
+			expected: &trackedTransactionStatus{
+				Status:     replacedStatus,
+				ReplacedBy: replacementTx.Hash().Hex(),
+			},
+		},
+		"replaced (mined)": {
+			pendingNonce: 4,
+			expected: &trackedTransactionStatus{
+				Status: replacedStatus,
+			},
+		},
+		"dropped": {
+			pendingNonce: 3,
+			expected: &trackedTransactionStatus{
+				Status: droppedStatus,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if test.receipt != nil {
+				mockClient.On("TransactionReceipt", ctx, signedTx.Hash()).Return(test.receipt, nil).Once()
+			} else {
+				mockClient.On("TransactionReceipt", ctx, signedTx.Hash()).Return(nil, ethereum.NotFound).Once()
+				if test.pendingTx != nil {
+					mockClient.On("PendingTransaction", ctx, from, uint64(3)).Return(
+						test.pendingTx,
+						test.pendingTx.Hash(),
+						nil,
+					).Once()
+				} else {
+					mockClient.On("PendingTransaction", ctx, from, uint64(3)).Return(nil, common.Hash{}, notFound).Once()
+					mockClient.On("PendingNonceAt", ctx, from).Return(test.pendingNonce, nil).Once()
+				}
+			}
+
+			test.expected.Hash = signedTx.Hash().Hex()
+			test.expected.From = from.Hex()
+			test.expected.Nonce = 3
+			test.expected.SubmittedAt = 1600000000000
+			result, err := types.MarshalMap(test.expected)
+			assert.NoError(t, err)
+
+			callResponse, rErr := callServicer.Call(ctx, &types.CallRequest{
+				Method: metadium.TransactionStatusMethod,
+				Parameters: map[string]interface{}{
+					"hash": signedTx.Hash().Hex(),
+				},
+			})
+			assert.Nil(t, rErr)
+			assert.Equal(t, &types.CallResponse{Result: result}, callResponse)
+		})
+	}
+
+	// Test Call (status unavailable)
+	mockClient.On("TransactionReceipt", ctx, signedTx.Hash()).Return(nil, errors.New("timeout")).Once()
+	callResponse, rErr := callServicer.Call(ctx, &types.CallRequest{
+		Method: metadium.TransactionStatusMethod,
+		Parameters: map[string]interface{}{
+			"hash": signedTx.Hash().Hex(),
+		},
+	})
+	assert.Nil(t, callResponse)
+	assert.Equal(t, ErrGmet.Code, rErr.Code)
+
+	// Test Call (transaction not submitted)
+	callResponse, rErr = callServicer.Call(ctx, &types.CallRequest{
+		Method: metadium.TransactionStatusMethod,
+		Parameters: map[string]interface{}{
+			"hash": replacementTx.Hash().Hex(),
+		},
+	})
+	assert.Nil(t, callResponse)
+	assert.Equal(t, ErrTransactionNotFound.Code, rErr.Code)
+
+	// Test Call (invalid hash)
+	callResponse, rErr = callServicer.Call(ctx, &types.CallRequest{
+		Method: metadium.TransactionStatusMethod,
+		Parameters: map[string]interface{}{
+			"hash": "0x1234",
+		},
+	})
+	assert.Nil(t, callResponse)
+	assert.Equal(t, ErrCallParametersInvalid.Code, rErr.Code)
+
+	// Test Rebroadcast (dropped transactions are broadcast again)
+	mockClient.On("TransactionReceipt", ctx, signedTx.Hash()).Return(nil, ethereum.NotFound).Once()
+	mockClient.On("PendingTransaction", ctx, from, uint64(3)).Return(nil, common.Hash{}, notFound).Once()
+	mockClient.On("PendingNonceAt", ctx, from).Return(uint64(3), nil).Once()
+	mockClient.On(
+		"SendTransaction",
+		ctx,
+		mock.MatchedBy(func(tx *ethTypes.Transaction) bool { return tx.Hash() == signedTx.Hash() }),
+	).Return(nil).Once()
+	tracker.rebroadcastDropped(ctx)
+
+	// Test Rebroadcast (pending transactions are not broadcast again)
+	mockClient.On("TransactionReceipt", ctx, signedTx.Hash()).Return(nil, ethereum.NotFound).Once()
+	mockClient.On("PendingTransaction", ctx, from, uint64(3)).Return(signedTx, signedTx.Hash(), nil).Once()
+	tracker.rebroadcastDropped(ctx)
+
+	// Test Status (fee-delegated transactions are tracked by the hash
+	// of the fee-delegated transaction, not of the sender transaction)
+	feeDelegatedHash := common.HexToHash("0x3f3b3c9e1ab1d2e1f1b1b2a2a5c2d9d55c1cbe42b0d36d7b04bd6bd3a1e7d3c1")
+	replacementHash := common.HexToHash("0x8d4f4e5dd0d8b4c5bb5b0a1fbd22c1a1f2b0e0a9d96e7d0cd6e3a5e42f7b0c22")
+	feeDelegatedTx := &trackedTransaction{hash: feeDelegatedHash, from: from, nonce: 3, signedTx: signedTx}
+	mockClient.On("TransactionReceipt", ctx, feeDelegatedHash).Return(nil, ethereum.NotFound).Twice()
+	mockClient.On("PendingTransaction", ctx, from, uint64(3)).Return(signedTx, feeDelegatedHash, nil).Once()
+	status, statusErr := tracker.status(ctx, feeDelegatedTx)
+	assert.NoError(t, statusErr)
+	assert.Equal(t, pendingStatus, status.Status)
+
+	// The same sender transaction with another fee payer is a replacement
+	mockClient.On("PendingTransaction", ctx, from, uint64(3)).Return(signedTx, replacementHash, nil).Once()
+	status, statusErr = tracker.status(ctx, feeDelegatedTx)
+	assert.NoError(t, statusErr)
+	assert.Equal(t, replacedStatus, status.Status)
+	assert.Equal(t, replacementHash.Hex(), status.ReplacedBy)
+
+	// Transactions are tracked for a day
+	now = now.Add(trackedTransactionTTL)
+	tracker.record(&trackedTransaction{hash: replacementTx.Hash(), signedTx: replacementTx})
+	assert.Nil(t, tracker.get(signedTx.Hash()))
+	assert.NotNil(t, tracker.get(replacementTx.Hash()))
+
+	mockClient.AssertExpectations(t)
+}
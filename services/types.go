@@ -16,10 +16,14 @@ package services
 
 import (
 	"context"
+	"encoding"
 	"encoding/json"
 	"math/big"
 
+	"github.com/metadium/rosetta-metadium/metadium"
+
 	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	ethTypes "github.com/ethereum/go-ethereum/core/types"
@@ -41,18 +45,54 @@ type Client interface {
 		*types.PartialBlockIdentifier,
 	) (*types.Block, error)
 
+	Transaction(
+		context.Context,
+		*types.BlockIdentifier,
+		*types.TransactionIdentifier,
+	) (*types.Transaction, error)
+
 	Balance(
 		context.Context,
 		*types.AccountIdentifier,
 		*types.PartialBlockIdentifier,
+		[]*types.Currency,
 	) (*types.AccountBalanceResponse, error)
 
+	Mempool(context.Context) ([]*types.TransactionIdentifier, error)
+
+	MempoolTransaction(
+		context.Context,
+		*types.TransactionIdentifier,
+	) (*types.Transaction, error)
+
 	PendingNonceAt(context.Context, common.Address) (uint64, error)
 
+	PendingTransaction(context.Context, common.Address, uint64) (*ethTypes.Transaction, common.Hash, error)
+
+	TransactionReceipt(context.Context, common.Hash) (*metadium.Receipt, error)
+
 	SuggestGasPrice(ctx context.Context) (*big.Int, error)
 
+	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
+
+	HeaderByNumber(ctx context.Context, number *big.Int) (*ethTypes.Header, error)
+
+	FeeHistory(
+		ctx context.Context,
+		blockCount uint64,
+		rewardPercentiles []float64,
+	) (*metadium.FeeHistory, error)
+
+	EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error)
+
+	CreateAccessList(ctx context.Context, msg ethereum.CallMsg) (ethTypes.AccessList, error)
+
+	DryRun(ctx context.Context, msg ethereum.CallMsg) (*metadium.DryRun, error)
+
 	SendTransaction(ctx context.Context, tx *ethTypes.Transaction) error
 
+	SendRawTransaction(ctx context.Context, rawTx []byte) error
+
 	Call(
 		ctx context.Context,
 		request *types.CallRequest,
@@ -60,23 +100,307 @@ type Client interface {
 }
 
 type options struct {
-	From string `json:"from"`
+	From     string   `json:"from"`
+	To       string   `json:"to,omitempty"`
+	Value    *big.Int `json:"value,omitempty"`
+	Data     []byte   `json:"data,omitempty"`
+	FeePayer string   `json:"fee_payer,omitempty"`
+
+	// MethodSignature and MethodArgs are only populated for
+	// contract calls, in which case Data holds their calldata.
+	MethodSignature string   `json:"method_signature,omitempty"`
+	MethodArgs      []string `json:"method_args,omitempty"`
+
+	// Nonce overrides the pending nonce of From. When Replace
+	// is set, the gas price is raised above the price of the
+	// pending transaction with the same nonce.
+	Nonce   *uint64 `json:"nonce,omitempty"`
+	Replace bool    `json:"replace,omitempty"`
+
+	// FeeTier selects fees from the fees paid in recent
+	// blocks instead of the fees suggested by gmet.
+	FeeTier feeTier `json:"fee_tier,omitempty"`
+
+	// RawTransaction makes /construction/combine return the
+	// signed transaction as hex encoded RLP instead of JSON. It
+	// is not supported for ERC-20 transfers and contract calls
+	// with a method signature, as they would parse as META calls.
+	RawTransaction bool `json:"raw_transaction,omitempty"`
+
+	// SignatureType is the signature type requested in the
+	// payloads returned by /construction/payloads.
+	SignatureType types.SignatureType `json:"signature_type,omitempty"`
+
+	// AccessList is the EIP-2930 access list of the transaction.
+	// When CreateAccessList is set, it is generated by gmet.
+	AccessList       ethTypes.AccessList `json:"access_list,omitempty"`
+	CreateAccessList bool                `json:"create_access_list,omitempty"`
+
+	// Batch is only populated for batches of transfers, in
+	// which case it holds the options of each transfer.
+	Batch []*options `json:"batch,omitempty"`
+}
+
+type optionsWire struct {
+	From            string              `json:"from"`
+	To              string              `json:"to,omitempty"`
+	Value           string              `json:"value,omitempty"`
+	Data            string              `json:"data,omitempty"`
+	FeePayer        string              `json:"fee_payer,omitempty"`
+	MethodSignature string              `json:"method_signature,omitempty"`
+	MethodArgs      []string            `json:"method_args,omitempty"`
+	Nonce           string              `json:"nonce,omitempty"`
+	Replace         bool                `json:"replace,omitempty"`
+	FeeTier         feeTier             `json:"fee_tier,omitempty"`
+	RawTransaction  bool                `json:"raw_transaction,omitempty"`
+	SignatureType   types.SignatureType `json:"signature_type,omitempty"`
+
+	AccessList       ethTypes.AccessList `json:"access_list,omitempty"`
+	CreateAccessList bool                `json:"create_access_list,omitempty"`
+
+	Batch []*options `json:"batch,omitempty"`
+}
+
+func (o *options) MarshalJSON() ([]byte, error) {
+	ow := &optionsWire{
+		From:            o.From,
+		To:              o.To,
+		FeePayer:        o.FeePayer,
+		MethodSignature: o.MethodSignature,
+		MethodArgs:      o.MethodArgs,
+		Replace:         o.Replace,
+		FeeTier:         o.FeeTier,
+		RawTransaction:  o.RawTransaction,
+		SignatureType:   o.SignatureType,
+
+		AccessList:       o.AccessList,
+		CreateAccessList: o.CreateAccessList,
+
+		Batch: o.Batch,
+	}
+	if o.Value != nil {
+		ow.Value = hexutil.EncodeBig(o.Value)
+	}
+	if len(o.Data) > 0 {
+		ow.Data = hexutil.Encode(o.Data)
+	}
+	if o.Nonce != nil {
+		ow.Nonce = hexutil.EncodeUint64(*o.Nonce)
+	}
+
+	return json.Marshal(ow)
+}
+
+func (o *options) UnmarshalJSON(data []byte) error {
+	var ow optionsWire
+	if err := json.Unmarshal(data, &ow); err != nil {
+		return err
+	}
+
+	if len(ow.Value) > 0 {
+		value, err := hexutil.DecodeBig(ow.Value)
+		if err != nil {
+			return err
+		}
+		o.Value = value
+	}
+
+	if len(ow.Data) > 0 {
+		owData, err := hexutil.Decode(ow.Data)
+		if err != nil {
+			return err
+		}
+		o.Data = owData
+	}
+
+	if len(ow.Nonce) > 0 {
+		nonce, err := hexutil.DecodeUint64(ow.Nonce)
+		if err != nil {
+			return err
+		}
+		o.Nonce = &nonce
+	}
+
+	o.From = ow.From
+	o.To = ow.To
+	o.FeePayer = ow.FeePayer
+	o.MethodSignature = ow.MethodSignature
+	o.MethodArgs = ow.MethodArgs
+	o.Replace = ow.Replace
+	o.FeeTier = ow.FeeTier
+	o.RawTransaction = ow.RawTransaction
+	o.SignatureType = ow.SignatureType
+	o.AccessList = ow.AccessList
+	o.CreateAccessList = ow.CreateAccessList
+	o.Batch = ow.Batch
+	return nil
+}
+
+// deriveMetadata is the metadata accepted by /construction/derive
+// to derive the key at DerivationPath from the BIP-32 extended
+// public key XPub, as in m/44'/916'/0'/0/0. The path must lead to
+// XPub and only have non-hardened components past its depth.
+type deriveMetadata struct {
+	XPub           string `json:"xpub,omitempty"`
+	DerivationPath string `json:"derivation_path,omitempty"`
+}
+
+// preprocessMetadata is the metadata accepted
+// by /construction/preprocess.
+type preprocessMetadata struct {
+	// FeePayer is the account paying the fee of a
+	// fee-delegated transaction.
+	FeePayer string `json:"fee_payer,omitempty"`
+
+	// Nonce overrides the pending nonce of the sender. Replace
+	// replaces the pending transaction with this nonce, and Cancel
+	// replaces it with a zero-value transfer to the sender.
+	Nonce   *hexutil.Uint64 `json:"nonce,omitempty"`
+	Replace bool            `json:"replace,omitempty"`
+	Cancel  bool            `json:"cancel,omitempty"`
+
+	// FeeTier is one of "slow", "normal" or "fast".
+	FeeTier feeTier `json:"fee_tier,omitempty"`
+
+	// RawTransaction requests the signed transaction
+	// as hex encoded RLP instead of JSON.
+	RawTransaction bool `json:"raw_transaction,omitempty"`
+
+	// SignatureType is either "ecdsa_recovery", the default,
+	// or "ecdsa" for signers that do not return a recovery id.
+	SignatureType types.SignatureType `json:"signature_type,omitempty"`
+
+	// AccessList is an EIP-2930 access list, as in the JSON of
+	// gmet transactions. CreateAccessList generates it with
+	// eth_createAccessList instead. Both require Berlin.
+	AccessList       ethTypes.AccessList `json:"access_list,omitempty"`
+	CreateAccessList bool                `json:"create_access_list,omitempty"`
+
+	contractCall
+	contractCreation
+}
+
+// contractCall is a call to a contract method, described either
+// by its method signature and arguments or by raw calldata.
+type contractCall struct {
+	MethodSignature string        `json:"method_signature,omitempty"`
+	MethodArgs      []string      `json:"method_args,omitempty"`
+	Data            hexutil.Bytes `json:"data,omitempty"`
+}
+
+// isSet returns a boolean indicating if c
+// describes a contract call.
+func (c *contractCall) isSet() bool {
+	return len(c.MethodSignature) > 0 || len(c.Data) > 0
+}
+
+// contractCreation is the deployment of a contract
+// with optional constructor arguments.
+type contractCreation struct {
+	Bytecode             hexutil.Bytes `json:"bytecode,omitempty"`
+	ConstructorSignature string        `json:"constructor_signature,omitempty"`
+	ConstructorArgs      []string      `json:"constructor_args,omitempty"`
+}
+
+// isSet returns a boolean indicating if c
+// describes a contract deployment.
+func (c *contractCreation) isSet() bool {
+	return len(c.Bytecode) > 0
+}
+
+// initCode returns the init code deploying c.
+func (c *contractCreation) initCode() ([]byte, error) {
+	return metadium.ContractCreationData(c.Bytecode, c.ConstructorSignature, c.ConstructorArgs)
+}
+
+// calldata returns the ABI encoded calldata of c.
+func (c *contractCall) calldata() ([]byte, error) {
+	if len(c.MethodSignature) == 0 {
+		return c.Data, nil
+	}
+
+	return metadium.ContractCallData(c.MethodSignature, c.MethodArgs)
 }
 
 type metadata struct {
 	Nonce    uint64   `json:"nonce"`
 	GasPrice *big.Int `json:"gas_price"`
+	GasLimit uint64   `json:"gas_limit"`
+
+	// BaseFee, GasFeeCap and GasTipCap are only
+	// populated once London is active.
+	BaseFee   *big.Int `json:"base_fee,omitempty"`
+	GasFeeCap *big.Int `json:"max_fee_per_gas,omitempty"`
+	GasTipCap *big.Int `json:"max_priority_fee_per_gas,omitempty"`
+
+	// FeePayer is only populated for fee-delegated transactions. The
+	// fee payer signs over the sender signature, so its payload is only
+	// returned once SenderSignature, taken from the transaction returned
+	// by /construction/combine for the sender, is added to the metadata.
+	FeePayer        string `json:"fee_payer,omitempty"`
+	SenderSignature []byte `json:"sender_signature,omitempty"`
+
+	// Data, MethodSignature and MethodArgs are only
+	// populated for contract calls.
+	Data            []byte   `json:"data,omitempty"`
+	MethodSignature string   `json:"method_signature,omitempty"`
+	MethodArgs      []string `json:"method_args,omitempty"`
+
+	// ContractAddress is only populated for contract
+	// deployments, in which case Data holds the init code.
+	ContractAddress string `json:"contract_address,omitempty"`
+
+	RawTransaction bool                `json:"raw_transaction,omitempty"`
+	SignatureType  types.SignatureType `json:"signature_type,omitempty"`
+
+	// AccessList is only populated for EIP-2930
+	// transactions and EIP-1559 transactions with
+	// an access list.
+	AccessList ethTypes.AccessList `json:"access_list,omitempty"`
 }
 
 type metadataWire struct {
-	Nonce    string `json:"nonce"`
-	GasPrice string `json:"gas_price"`
+	Nonce           string `json:"nonce"`
+	GasPrice        string `json:"gas_price"`
+	GasLimit        string `json:"gas_limit,omitempty"`
+	BaseFee         string `json:"base_fee,omitempty"`
+	GasFeeCap       string `json:"max_fee_per_gas,omitempty"`
+	GasTipCap       string `json:"max_priority_fee_per_gas,omitempty"`
+	FeePayer        string `json:"fee_payer,omitempty"`
+	SenderSignature string `json:"sender_signature,omitempty"`
+
+	Data            string              `json:"data,omitempty"`
+	MethodSignature string              `json:"method_signature,omitempty"`
+	MethodArgs      []string            `json:"method_args,omitempty"`
+	ContractAddress string              `json:"contract_address,omitempty"`
+	RawTransaction  bool                `json:"raw_transaction,omitempty"`
+	SignatureType   types.SignatureType `json:"signature_type,omitempty"`
+	AccessList      ethTypes.AccessList `json:"access_list,omitempty"`
 }
 
 func (m *metadata) MarshalJSON() ([]byte, error) {
 	mw := &metadataWire{
-		Nonce:    hexutil.Uint64(m.Nonce).String(),
-		GasPrice: hexutil.EncodeBig(m.GasPrice),
+		Nonce:           hexutil.Uint64(m.Nonce).String(),
+		GasPrice:        hexutil.EncodeBig(m.GasPrice),
+		BaseFee:         encodeOptionalBig(m.BaseFee),
+		GasFeeCap:       encodeOptionalBig(m.GasFeeCap),
+		GasTipCap:       encodeOptionalBig(m.GasTipCap),
+		FeePayer:        m.FeePayer,
+		MethodSignature: m.MethodSignature,
+		MethodArgs:      m.MethodArgs,
+		ContractAddress: m.ContractAddress,
+		RawTransaction:  m.RawTransaction,
+		SignatureType:   m.SignatureType,
+		AccessList:      m.AccessList,
+	}
+	if m.GasLimit > 0 {
+		mw.GasLimit = hexutil.Uint64(m.GasLimit).String()
+	}
+	if len(m.SenderSignature) > 0 {
+		mw.SenderSignature = hexutil.Encode(m.SenderSignature)
+	}
+	if len(m.Data) > 0 {
+		mw.Data = hexutil.Encode(m.Data)
 	}
 
 	return json.Marshal(mw)
@@ -98,35 +422,137 @@ func (m *metadata) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
+	if len(mw.GasLimit) > 0 {
+		gasLimit, err := hexutil.DecodeUint64(mw.GasLimit)
+		if err != nil {
+			return err
+		}
+		m.GasLimit = gasLimit
+	}
+
+	baseFee, err := decodeOptionalBig(mw.BaseFee)
+	if err != nil {
+		return err
+	}
+
+	gasFeeCap, err := decodeOptionalBig(mw.GasFeeCap)
+	if err != nil {
+		return err
+	}
+
+	gasTipCap, err := decodeOptionalBig(mw.GasTipCap)
+	if err != nil {
+		return err
+	}
+
+	if len(mw.SenderSignature) > 0 {
+		senderSignature, err := hexutil.Decode(mw.SenderSignature)
+		if err != nil {
+			return err
+		}
+		m.SenderSignature = senderSignature
+	}
+
+	if len(mw.Data) > 0 {
+		mwData, err := hexutil.Decode(mw.Data)
+		if err != nil {
+			return err
+		}
+		m.Data = mwData
+	}
+
 	m.GasPrice = gasPrice
 	m.Nonce = nonce
+	m.BaseFee = baseFee
+	m.GasFeeCap = gasFeeCap
+	m.GasTipCap = gasTipCap
+	m.FeePayer = mw.FeePayer
+	m.MethodSignature = mw.MethodSignature
+	m.MethodArgs = mw.MethodArgs
+	m.ContractAddress = mw.ContractAddress
+	m.RawTransaction = mw.RawTransaction
+	m.SignatureType = mw.SignatureType
+	m.AccessList = mw.AccessList
 	return nil
 }
 
 type parseMetadata struct {
-	Nonce    uint64   `json:"nonce"`
-	GasPrice *big.Int `json:"gas_price"`
-	ChainID  *big.Int `json:"chain_id"`
+	Nonce     uint64   `json:"nonce"`
+	GasPrice  *big.Int `json:"gas_price"`
+	ChainID   *big.Int `json:"chain_id"`
+	GasFeeCap *big.Int `json:"max_fee_per_gas,omitempty"`
+	GasTipCap *big.Int `json:"max_priority_fee_per_gas,omitempty"`
+	FeePayer  string   `json:"fee_payer,omitempty"`
+
+	// Data is only populated for contract calls made with raw
+	// calldata, MethodSignature and MethodArgs for the others.
+	Data            []byte   `json:"data,omitempty"`
+	MethodSignature string   `json:"method_signature,omitempty"`
+	MethodArgs      []string `json:"method_args,omitempty"`
+
+	// ContractAddress is only populated for contract
+	// deployments, in which case Data holds the init code.
+	ContractAddress string `json:"contract_address,omitempty"`
+
+	// AccessList is only populated for
+	// transactions with an access list.
+	AccessList ethTypes.AccessList `json:"access_list,omitempty"`
 }
 
 type parseMetadataWire struct {
-	Nonce    string `json:"nonce"`
-	GasPrice string `json:"gas_price"`
-	ChainID  string `json:"chain_id"`
+	Nonce           string   `json:"nonce"`
+	GasPrice        string   `json:"gas_price"`
+	ChainID         string   `json:"chain_id"`
+	GasFeeCap       string   `json:"max_fee_per_gas,omitempty"`
+	GasTipCap       string   `json:"max_priority_fee_per_gas,omitempty"`
+	FeePayer        string   `json:"fee_payer,omitempty"`
+	Data            string   `json:"data,omitempty"`
+	MethodSignature string   `json:"method_signature,omitempty"`
+	MethodArgs      []string `json:"method_args,omitempty"`
+	ContractAddress string   `json:"contract_address,omitempty"`
+
+	AccessList ethTypes.AccessList `json:"access_list,omitempty"`
 }
 
 func (p *parseMetadata) MarshalJSON() ([]byte, error) {
 	pmw := &parseMetadataWire{
-		Nonce:    hexutil.Uint64(p.Nonce).String(),
-		GasPrice: hexutil.EncodeBig(p.GasPrice),
-		ChainID:  hexutil.EncodeBig(p.ChainID),
+		Nonce:           hexutil.Uint64(p.Nonce).String(),
+		GasPrice:        hexutil.EncodeBig(p.GasPrice),
+		ChainID:         hexutil.EncodeBig(p.ChainID),
+		GasFeeCap:       encodeOptionalBig(p.GasFeeCap),
+		GasTipCap:       encodeOptionalBig(p.GasTipCap),
+		FeePayer:        p.FeePayer,
+		MethodSignature: p.MethodSignature,
+		MethodArgs:      p.MethodArgs,
+		ContractAddress: p.ContractAddress,
+		AccessList:      p.AccessList,
+	}
+	if len(p.Data) > 0 {
+		pmw.Data = hexutil.Encode(p.Data)
 	}
 
 	return json.Marshal(pmw)
 }
 
+// signedTransaction is a signed transaction, either an
+// *ethTypes.Transaction or a *metadium.FeeDelegatedTransaction.
+type signedTransaction interface {
+	json.Marshaler
+	encoding.BinaryMarshaler
+}
+
+// signedTransactionExtra holds the fields attached to the
+// go-ethereum JSON of a signed transaction.
+type signedTransactionExtra struct {
+	Currency        *types.Currency `json:"currency,omitempty"`
+	MethodSignature string          `json:"method_signature,omitempty"`
+	MethodArgs      []string        `json:"method_args,omitempty"`
+}
+
 type transaction struct {
-	From     string   `json:"from"`
+	From string `json:"from"`
+
+	// To is empty for contract deployments.
 	To       string   `json:"to"`
 	Value    *big.Int `json:"value"`
 	Data     []byte   `json:"data"`
@@ -134,6 +560,40 @@ type transaction struct {
 	GasPrice *big.Int `json:"gas_price"`
 	GasLimit uint64   `json:"gas"`
 	ChainID  *big.Int `json:"chain_id"`
+
+	// GasFeeCap and GasTipCap are only populated for
+	// EIP-1559 transactions.
+	GasFeeCap *big.Int `json:"max_fee_per_gas,omitempty"`
+	GasTipCap *big.Int `json:"max_priority_fee_per_gas,omitempty"`
+
+	// FeePayer and SenderSignature are only populated
+	// for fee-delegated transactions.
+	FeePayer        string `json:"fee_payer,omitempty"`
+	SenderSignature []byte `json:"sender_signature,omitempty"`
+
+	// Currency is only populated for ERC-20 transfers.
+	Currency *types.Currency `json:"currency,omitempty"`
+
+	// MethodSignature and MethodArgs are only populated for
+	// contract calls made with a method signature.
+	MethodSignature string   `json:"method_signature,omitempty"`
+	MethodArgs      []string `json:"method_args,omitempty"`
+
+	// ContractAddress is only populated for contract deployments.
+	ContractAddress string `json:"contract_address,omitempty"`
+
+	// RawTransaction makes /construction/combine return
+	// hex encoded RLP instead of JSON.
+	RawTransaction bool `json:"raw_transaction,omitempty"`
+
+	// SignatureType is the signature type of the payloads. For
+	// "ecdsa", /construction/combine recovers the recovery id.
+	SignatureType types.SignatureType `json:"signature_type,omitempty"`
+
+	// AccessList is only populated for transactions with an
+	// access list, which are built as EIP-2930 transactions
+	// unless GasFeeCap and GasTipCap are populated.
+	AccessList ethTypes.AccessList `json:"access_list,omitempty"`
 }
 
 type transactionWire struct {
@@ -145,6 +605,24 @@ type transactionWire struct {
 	GasPrice string `json:"gas_price"`
 	GasLimit string `json:"gas"`
 	ChainID  string `json:"chain_id"`
+
+	GasFeeCap string `json:"max_fee_per_gas,omitempty"`
+	GasTipCap string `json:"max_priority_fee_per_gas,omitempty"`
+
+	FeePayer        string `json:"fee_payer,omitempty"`
+	SenderSignature string `json:"sender_signature,omitempty"`
+
+	Currency *types.Currency `json:"currency,omitempty"`
+
+	MethodSignature string   `json:"method_signature,omitempty"`
+	MethodArgs      []string `json:"method_args,omitempty"`
+
+	ContractAddress string `json:"contract_address,omitempty"`
+
+	RawTransaction bool                `json:"raw_transaction,omitempty"`
+	SignatureType  types.SignatureType `json:"signature_type,omitempty"`
+
+	AccessList ethTypes.AccessList `json:"access_list,omitempty"`
 }
 
 func (t *transaction) MarshalJSON() ([]byte, error) {
@@ -157,6 +635,26 @@ func (t *transaction) MarshalJSON() ([]byte, error) {
 		GasPrice: hexutil.EncodeBig(t.GasPrice),
 		GasLimit: hexutil.EncodeUint64(t.GasLimit),
 		ChainID:  hexutil.EncodeBig(t.ChainID),
+
+		GasFeeCap: encodeOptionalBig(t.GasFeeCap),
+		GasTipCap: encodeOptionalBig(t.GasTipCap),
+
+		FeePayer: t.FeePayer,
+
+		Currency: t.Currency,
+
+		MethodSignature: t.MethodSignature,
+		MethodArgs:      t.MethodArgs,
+
+		ContractAddress: t.ContractAddress,
+
+		RawTransaction: t.RawTransaction,
+		SignatureType:  t.SignatureType,
+
+		AccessList: t.AccessList,
+	}
+	if len(t.SenderSignature) > 0 {
+		tw.SenderSignature = hexutil.Encode(t.SenderSignature)
 	}
 
 	return json.Marshal(tw)
@@ -198,6 +696,24 @@ func (t *transaction) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
+	gasFeeCap, err := decodeOptionalBig(tw.GasFeeCap)
+	if err != nil {
+		return err
+	}
+
+	gasTipCap, err := decodeOptionalBig(tw.GasTipCap)
+	if err != nil {
+		return err
+	}
+
+	if len(tw.SenderSignature) > 0 {
+		senderSignature, err := hexutil.Decode(tw.SenderSignature)
+		if err != nil {
+			return err
+		}
+		t.SenderSignature = senderSignature
+	}
+
 	t.From = tw.From
 	t.To = tw.To
 	t.Value = value
@@ -207,5 +723,53 @@ func (t *transaction) UnmarshalJSON(data []byte) error {
 	t.GasLimit = gasLimit
 	t.ChainID = chainID
 	t.GasPrice = gasPrice
+	t.GasFeeCap = gasFeeCap
+	t.GasTipCap = gasTipCap
+	t.FeePayer = tw.FeePayer
+	t.Currency = tw.Currency
+	t.MethodSignature = tw.MethodSignature
+	t.MethodArgs = tw.MethodArgs
+	t.ContractAddress = tw.ContractAddress
+	t.RawTransaction = tw.RawTransaction
+	t.SignatureType = tw.SignatureType
+	t.AccessList = tw.AccessList
 	return nil
 }
+
+// isDynamicFee returns a boolean indicating if
+// the transaction is an EIP-1559 transaction.
+func (t *transaction) isDynamicFee() bool {
+	return t.GasFeeCap != nil && t.GasTipCap != nil
+}
+
+// hasAccessList returns a boolean indicating
+// if the transaction has an access list.
+func (t *transaction) hasAccessList() bool {
+	return len(t.AccessList) > 0
+}
+
+// isFeeDelegated returns a boolean indicating if
+// the transaction is a fee-delegated transaction.
+func (t *transaction) isFeeDelegated() bool {
+	return len(t.FeePayer) > 0
+}
+
+// signatureType returns the signature type of the
+// payloads of t, which defaults to ecdsa_recovery.
+func (t *transaction) signatureType() types.SignatureType {
+	if len(t.SignatureType) == 0 {
+		return types.EcdsaRecovery
+	}
+
+	return t.SignatureType
+}
+
+// signedExtra returns the fields of t that are attached
+// to the JSON of the signed transaction.
+func (t *transaction) signedExtra() *signedTransactionExtra {
+	return &signedTransactionExtra{
+		Currency:        t.Currency,
+		MethodSignature: t.MethodSignature,
+		MethodArgs:      t.MethodArgs,
+	}
+}
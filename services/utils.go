@@ -16,6 +16,9 @@ package services
 
 import (
 	"encoding/json"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
 // *JSONMap functions are needed because `types.MarshalMap/types.UnmarshalMap`
@@ -45,3 +48,41 @@ func unmarshalJSONMap(m map[string]interface{}, i interface{}) error {
 
 	return json.Unmarshal(b, i)
 }
+
+// encodeOptionalBig hex encodes i, returning an
+// empty string if i is nil.
+func encodeOptionalBig(i *big.Int) string {
+	if i == nil {
+		return ""
+	}
+
+	return hexutil.EncodeBig(i)
+}
+
+// decodeOptionalBig decodes a hex encoded *big.Int,
+// returning nil if s is empty.
+func decodeOptionalBig(s string) (*big.Int, error) {
+	if len(s) == 0 {
+		return nil, nil
+	}
+
+	return hexutil.DecodeBig(s)
+}
+
+// maxBig returns the larger of a and b.
+func maxBig(a *big.Int, b *big.Int) *big.Int {
+	if a.Cmp(b) >= 0 {
+		return a
+	}
+
+	return b
+}
+
+// minBig returns the smaller of a and b.
+func minBig(a *big.Int, b *big.Int) *big.Int {
+	if a.Cmp(b) <= 0 {
+		return a
+	}
+
+	return b
+}